@@ -2,4 +2,11 @@ module github.com/wissensalt/paswot
 
 go 1.24.5
 
-require golang.org/x/crypto v0.45.0
+require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
+	github.com/rivo/uniseg v0.4.7
+	golang.org/x/crypto v0.45.0
+	golang.org/x/text v0.31.0
+)
+
+require golang.org/x/sys v0.38.0 // indirect
@@ -49,8 +49,14 @@ func testUnsaltedPassword(paswotRule *rule.PaswotRule) {
 
 func testSaltedPassword(paswotRule *rule.PaswotRule) {
 	println("########## Testing Salted Password ##########")
-	pasWithSalt := paswot.NewPaswotWithSalt("mySalt")
-	err := pasWithSalt.Generate(paswotRule)
+	pasWithSalt, err := paswot.NewPaswotWithRandomSalt(paswot.MinSaltLength)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	println("Generated Salt:", pasWithSalt.Salt)
+
+	err = pasWithSalt.Generate(paswotRule)
 	if err != nil {
 		println(err.Error())
 	}
@@ -0,0 +1,90 @@
+package paswot
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrUnknownAlgorithm          = errors.New("unknown hashing algorithm")
+	ErrMismatchedHashAndPassword = errors.New("hashed password does not match the given password")
+)
+
+// Algorithm hashes passwords into a self-describing string and compares
+// passwords against hashes it produced. NeedsRehash reports whether hashed,
+// produced by this algorithm, uses weaker parameters than the receiver.
+type Algorithm interface {
+	Name() string
+	Hash(password []byte) ([]byte, error)
+	Compare(hashed, password []byte) error
+	NeedsRehash(hashed []byte) bool
+}
+
+var (
+	algorithmsMu sync.RWMutex
+	algorithms   = map[string]Algorithm{}
+)
+
+func init() {
+	bcryptAlgorithm := DefaultBcryptAlgorithm()
+	for _, id := range []string{"2a", "2b", "2y"} {
+		RegisterAlgorithm(id, bcryptAlgorithm)
+	}
+	RegisterAlgorithm(bcryptSHA256ID, DefaultBcryptSHA256Algorithm())
+	RegisterAlgorithm(argon2idID, DefaultArgon2idAlgorithm())
+	RegisterAlgorithm(scryptID, DefaultScryptAlgorithm())
+	RegisterAlgorithm(pbkdf2SHA256ID, DefaultPBKDF2Algorithm())
+}
+
+// RegisterAlgorithm makes an algorithm available to Match for hashes whose
+// identifier (the text between the first two '$') equals id.
+func RegisterAlgorithm(id string, algorithm Algorithm) {
+	algorithmsMu.Lock()
+	defer algorithmsMu.Unlock()
+	algorithms[id] = algorithm
+}
+
+func LookupAlgorithm(id string) (Algorithm, bool) {
+	algorithmsMu.RLock()
+	defer algorithmsMu.RUnlock()
+	algorithm, ok := algorithms[id]
+	return algorithm, ok
+}
+
+func DefaultAlgorithm() Algorithm {
+	return DefaultBcryptAlgorithm()
+}
+
+func hashID(hashed string) string {
+	if !strings.HasPrefix(hashed, "$") {
+		return ""
+	}
+	id, _, _ := strings.Cut(hashed[1:], "$")
+	return id
+}
+
+func algorithmFor(hashed string) (Algorithm, error) {
+	algorithm, ok := LookupAlgorithm(hashID(hashed))
+	if !ok {
+		return nil, ErrUnknownAlgorithm
+	}
+	return algorithm, nil
+}
+
+func compare(hashed string, password []byte) error {
+	algorithm, err := algorithmFor(hashed)
+	if err != nil {
+		return err
+	}
+	return algorithm.Compare([]byte(hashed), password)
+}
+
+func randomBytes(n uint32) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
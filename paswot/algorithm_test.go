@@ -0,0 +1,84 @@
+package paswot
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLookupAlgorithm(t *testing.T) {
+	testCases := []struct {
+		id   string
+		name string
+	}{
+		{id: "2a", name: "bcrypt"},
+		{id: "2b", name: "bcrypt"},
+		{id: "2y", name: "bcrypt"},
+		{id: "bcrypt-sha256", name: "bcrypt-sha256"},
+		{id: "argon2id", name: "argon2id"},
+		{id: "scrypt", name: "scrypt"},
+		{id: "pbkdf2-sha256", name: "pbkdf2-sha256"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.id, func(t *testing.T) {
+			algorithm, ok := LookupAlgorithm(tc.id)
+			if !ok {
+				t.Fatalf("LookupAlgorithm(%q) should be registered", tc.id)
+			}
+			if algorithm.Name() != tc.name {
+				t.Errorf("LookupAlgorithm(%q).Name() = %q, want %q", tc.id, algorithm.Name(), tc.name)
+			}
+		})
+	}
+
+	if _, ok := LookupAlgorithm("md5"); ok {
+		t.Error("LookupAlgorithm(\"md5\") should not be registered")
+	}
+}
+
+func TestPaswot_HashWithAlgorithm(t *testing.T) {
+	testCases := []struct {
+		name      string
+		algorithm Algorithm
+		prefix    string
+	}{
+		{name: "Bcrypt", algorithm: NewBcryptAlgorithm(4), prefix: "$2a$04$"},
+		{name: "Argon2id", algorithm: NewArgon2idAlgorithm(1, 1024, 1), prefix: "$argon2id$v=19$m=1024,t=1,p=1$"},
+		{name: "Scrypt", algorithm: NewScryptAlgorithm(4, 8, 1), prefix: "$scrypt$ln=4,r=8,p=1$"},
+		{name: "PBKDF2", algorithm: NewPBKDF2Algorithm(1000), prefix: "$pbkdf2-sha256$i=1000$"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &WithSalt{Paswot: &Paswot{Plain: "password", Algorithm: tc.algorithm}, Salt: "salt"}
+
+			hashed, err := p.Hash()
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+			if got := string(hashed); len(got) < len(tc.prefix) || got[:len(tc.prefix)] != tc.prefix {
+				t.Errorf("Hash() = %q, want prefix %q", got, tc.prefix)
+			}
+
+			// Match does not depend on the configured algorithm
+			verifier := NewPaswotWithSalt("salt")
+			verifier.Plain = "password"
+			if !verifier.Match(string(hashed)) {
+				t.Error("Match() with correct password should be true, but got false")
+			}
+
+			verifier.Plain = "wrongpassword"
+			if verifier.Match(string(hashed)) {
+				t.Error("Match() with incorrect password should be false, but got true")
+			}
+		})
+	}
+}
+
+func TestCompare_UnknownAlgorithm(t *testing.T) {
+	for _, hashed := range []string{"", "plain", "$md5$abc$def"} {
+		if err := compare(hashed, []byte("password")); !errors.Is(err, ErrUnknownAlgorithm) {
+			t.Errorf("compare(%q) error = %v, want %v", hashed, err, ErrUnknownAlgorithm)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package paswot
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idID = "argon2id"
+
+// Limits on the parameters of stored argon2id hashes. A corrupted or
+// malicious record could otherwise make verification allocate more memory
+// than the process has.
+const (
+	maxArgon2idMemory  = 1 << 20 // KiB, 1 GiB
+	maxArgon2idTime    = 100
+	maxArgon2idThreads = 64
+)
+
+type Argon2idAlgorithm struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+	KeyLen  uint32
+	SaltLen uint32
+}
+
+func NewArgon2idAlgorithm(time, memory uint32, threads uint8) *Argon2idAlgorithm {
+	return &Argon2idAlgorithm{Time: time, Memory: memory, Threads: threads, KeyLen: 32, SaltLen: 16}
+}
+
+// DefaultArgon2idAlgorithm uses the OWASP baseline of 19 MiB memory, two
+// iterations and one lane.
+func DefaultArgon2idAlgorithm() *Argon2idAlgorithm {
+	return NewArgon2idAlgorithm(2, 19*1024, 1)
+}
+
+func (a *Argon2idAlgorithm) Name() string {
+	return argon2idID
+}
+
+func (a *Argon2idAlgorithm) Hash(password []byte) ([]byte, error) {
+	salt, err := randomBytes(a.SaltLen)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &PHC{
+		ID:      argon2idID,
+		Version: argon2.Version,
+		Params: []PHCParam{
+			{Key: "m", Value: strconv.FormatUint(uint64(a.Memory), 10)},
+			{Key: "t", Value: strconv.FormatUint(uint64(a.Time), 10)},
+			{Key: "p", Value: strconv.FormatUint(uint64(a.Threads), 10)},
+		},
+		Salt: salt,
+		Hash: argon2.IDKey(password, salt, a.Time, a.Memory, a.Threads, a.KeyLen),
+	}
+	return []byte(h.String()), nil
+}
+
+func (a *Argon2idAlgorithm) Compare(hashed, password []byte) error {
+	h, params, err := parseArgon2id(hashed)
+	if err != nil {
+		return err
+	}
+
+	otherKey := argon2.IDKey(password, h.Salt, params.Time, params.Memory, params.Threads, uint32(len(h.Hash)))
+	if subtle.ConstantTimeCompare(h.Hash, otherKey) != 1 {
+		return ErrMismatchedHashAndPassword
+	}
+	return nil
+}
+
+func (a *Argon2idAlgorithm) NeedsRehash(hashed []byte) bool {
+	_, params, err := parseArgon2id(hashed)
+	if err != nil {
+		return true
+	}
+	return params.Time < a.Time || params.Memory < a.Memory || params.Threads < a.Threads ||
+		params.KeyLen < a.KeyLen || params.SaltLen < a.SaltLen
+}
+
+func parseArgon2id(hashed []byte) (*PHC, *Argon2idAlgorithm, error) {
+	h, err := parsePHCFor(hashed, argon2idID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if h.Version != argon2.Version {
+		return nil, nil, fmt.Errorf("%w: unsupported argon2 version %d", ErrInvalidPHC, h.Version)
+	}
+
+	memory, err := h.IntParam("m")
+	if err != nil {
+		return nil, nil, err
+	}
+	time, err := h.IntParam("t")
+	if err != nil {
+		return nil, nil, err
+	}
+	threads, err := h.IntParam("p")
+	if err != nil {
+		return nil, nil, err
+	}
+	if memory <= 0 || memory > maxArgon2idMemory || time <= 0 || time > maxArgon2idTime || threads <= 0 || threads > maxArgon2idThreads {
+		return nil, nil, fmt.Errorf("%w: argon2 parameters out of range", ErrInvalidPHC)
+	}
+
+	params := &Argon2idAlgorithm{
+		Time:    uint32(time),
+		Memory:  uint32(memory),
+		Threads: uint8(threads),
+		KeyLen:  uint32(len(h.Hash)),
+		SaltLen: uint32(len(h.Salt)),
+	}
+	return h, params, nil
+}
@@ -0,0 +1,32 @@
+package paswot
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestArgon2idAlgorithm_Compare(t *testing.T) {
+	algorithm := NewArgon2idAlgorithm(1, 1024, 1)
+	hashed, err := algorithm.Hash([]byte("password"))
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	if err := algorithm.Compare(hashed, []byte("password")); err != nil {
+		t.Errorf("Compare() with correct password error = %v", err)
+	}
+	if err := algorithm.Compare(hashed, []byte("wrongpassword")); !errors.Is(err, ErrMismatchedHashAndPassword) {
+		t.Errorf("Compare() with incorrect password error = %v, want %v", err, ErrMismatchedHashAndPassword)
+	}
+
+	// Parameters are read from the hash, not from the receiver
+	if err := DefaultArgon2idAlgorithm().Compare(hashed, []byte("password")); err != nil {
+		t.Errorf("Compare() with different receiver parameters error = %v", err)
+	}
+
+	malformed := strings.Replace(string(hashed), "v=19", "v=x", 1)
+	if err := algorithm.Compare([]byte(malformed), []byte("password")); err == nil {
+		t.Error("Compare() with malformed hash should return an error")
+	}
+}
@@ -0,0 +1,71 @@
+package paswot
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptMaxInputLength is the number of input bytes bcrypt uses; anything
+// beyond it would be ignored.
+const BcryptMaxInputLength = 72
+
+// InputTooLongError is returned instead of silently truncating input that
+// exceeds what the algorithm can hash.
+type InputTooLongError struct {
+	Length int
+	Limit  int
+}
+
+func (e *InputTooLongError) Error() string {
+	return fmt.Sprintf("hash input is %d bytes, exceeding the %d-byte limit", e.Length, e.Limit)
+}
+
+type BcryptAlgorithm struct {
+	Cost int
+}
+
+func NewBcryptAlgorithm(cost int) *BcryptAlgorithm {
+	return &BcryptAlgorithm{Cost: cost}
+}
+
+func DefaultBcryptAlgorithm() *BcryptAlgorithm {
+	return NewBcryptAlgorithm(bcrypt.DefaultCost)
+}
+
+func (a *BcryptAlgorithm) Name() string {
+	return "bcrypt"
+}
+
+func (a *BcryptAlgorithm) Hash(password []byte) ([]byte, error) {
+	if len(password) > BcryptMaxInputLength {
+		return nil, &InputTooLongError{Length: len(password), Limit: BcryptMaxInputLength}
+	}
+	return bcrypt.GenerateFromPassword(password, a.Cost)
+}
+
+func (a *BcryptAlgorithm) NeedsRehash(hashed []byte) bool {
+	cost, err := bcrypt.Cost(hashed)
+	if err != nil {
+		return true
+	}
+	return cost < a.cost()
+}
+
+func (a *BcryptAlgorithm) cost() int {
+	if a.Cost < bcrypt.MinCost {
+		return bcrypt.DefaultCost
+	}
+	return a.Cost
+}
+
+func (a *BcryptAlgorithm) Compare(hashed, password []byte) error {
+	if len(password) > BcryptMaxInputLength {
+		return &InputTooLongError{Length: len(password), Limit: BcryptMaxInputLength}
+	}
+	err := bcrypt.CompareHashAndPassword(hashed, password)
+	if err == bcrypt.ErrMismatchedHashAndPassword {
+		return ErrMismatchedHashAndPassword
+	}
+	return err
+}
@@ -0,0 +1,31 @@
+package paswot
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestBcryptAlgorithm_Hash(t *testing.T) {
+	algorithm := NewBcryptAlgorithm(bcrypt.MinCost)
+	hashed, err := algorithm.Hash([]byte("password"))
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	cost, err := bcrypt.Cost(hashed)
+	if err != nil {
+		t.Fatalf("bcrypt.Cost() error = %v", err)
+	}
+	if cost != bcrypt.MinCost {
+		t.Errorf("Hash() cost = %d, want %d", cost, bcrypt.MinCost)
+	}
+
+	if err := algorithm.Compare(hashed, []byte("password")); err != nil {
+		t.Errorf("Compare() with correct password error = %v", err)
+	}
+	if err := algorithm.Compare(hashed, []byte("wrongpassword")); !errors.Is(err, ErrMismatchedHashAndPassword) {
+		t.Errorf("Compare() with incorrect password error = %v, want %v", err, ErrMismatchedHashAndPassword)
+	}
+}
@@ -0,0 +1,103 @@
+package paswot
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const bcryptSHA256ID = "bcrypt-sha256"
+
+// BcryptSHA256Algorithm pre-hashes input with HMAC-SHA256, keyed by a random
+// per-hash salt, and base64 encodes the result before passing it to bcrypt.
+// The encoded digest is always 44 bytes, so input of any length contributes
+// to the hash. Hashes are stored as
+//
+//	$bcrypt-sha256$r=<cost>$<hmac salt>$<bcrypt hash>
+type BcryptSHA256Algorithm struct {
+	Cost    int
+	SaltLen uint32
+}
+
+func NewBcryptSHA256Algorithm(cost int) *BcryptSHA256Algorithm {
+	return &BcryptSHA256Algorithm{Cost: cost, SaltLen: 16}
+}
+
+func DefaultBcryptSHA256Algorithm() *BcryptSHA256Algorithm {
+	return NewBcryptSHA256Algorithm(bcrypt.DefaultCost)
+}
+
+func (a *BcryptSHA256Algorithm) Name() string {
+	return bcryptSHA256ID
+}
+
+func (a *BcryptSHA256Algorithm) Hash(password []byte) ([]byte, error) {
+	salt, err := randomBytes(a.SaltLen)
+	if err != nil {
+		return nil, err
+	}
+	hashed, err := bcrypt.GenerateFromPassword(hmacBase64(salt, password), a.Cost)
+	if err != nil {
+		return nil, err
+	}
+	cost, err := bcrypt.Cost(hashed)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &PHC{
+		ID:     bcryptSHA256ID,
+		Params: []PHCParam{{Key: "r", Value: strconv.Itoa(cost)}},
+		Salt:   salt,
+		Hash:   hashed,
+	}
+	return []byte(h.String()), nil
+}
+
+func (a *BcryptSHA256Algorithm) Compare(hashed, password []byte) error {
+	h, err := parseBcryptSHA256(hashed)
+	if err != nil {
+		return err
+	}
+
+	err = bcrypt.CompareHashAndPassword(h.Hash, hmacBase64(h.Salt, password))
+	if err == bcrypt.ErrMismatchedHashAndPassword {
+		return ErrMismatchedHashAndPassword
+	}
+	return err
+}
+
+func (a *BcryptSHA256Algorithm) NeedsRehash(hashed []byte) bool {
+	h, err := parseBcryptSHA256(hashed)
+	if err != nil {
+		return true
+	}
+	cost, err := bcrypt.Cost(h.Hash)
+	if err != nil {
+		return true
+	}
+	return cost < NewBcryptAlgorithm(a.Cost).cost() || uint32(len(h.Salt)) < a.SaltLen
+}
+
+func parseBcryptSHA256(hashed []byte) (*PHC, error) {
+	h, err := parsePHCFor(hashed, bcryptSHA256ID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := bcrypt.Cost(h.Hash); err != nil {
+		return nil, fmt.Errorf("%w: invalid bcrypt hash", ErrInvalidPHC)
+	}
+	return h, nil
+}
+
+// hmacBase64 returns the base64 encoded HMAC-SHA256 of input. At 44 bytes it
+// is short enough for any algorithm, including bcrypt.
+func hmacBase64(key, input []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(input)
+	return []byte(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+}
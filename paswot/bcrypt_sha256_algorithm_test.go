@@ -0,0 +1,78 @@
+package paswot
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestBcryptSHA256Algorithm_Compare(t *testing.T) {
+	algorithm := NewBcryptSHA256Algorithm(bcrypt.MinCost)
+	long := []byte(strings.Repeat("a", 100))
+
+	hashed, err := algorithm.Hash(long)
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if !strings.HasPrefix(string(hashed), "$bcrypt-sha256$r=4$") {
+		t.Errorf("Hash() = %q, want prefix %q", hashed, "$bcrypt-sha256$r=4$")
+	}
+
+	if err := algorithm.Compare(hashed, long); err != nil {
+		t.Errorf("Compare() with correct password error = %v", err)
+	}
+
+	// Bytes past bcrypt's limit still matter
+	truncated := append(long[:BcryptMaxInputLength:BcryptMaxInputLength], 'b')
+	if err := algorithm.Compare(hashed, truncated); !errors.Is(err, ErrMismatchedHashAndPassword) {
+		t.Errorf("Compare() differing after 72 bytes error = %v, want %v", err, ErrMismatchedHashAndPassword)
+	}
+
+	if algorithm.NeedsRehash(hashed) {
+		t.Error("NeedsRehash() with same cost should be false")
+	}
+	if !NewBcryptSHA256Algorithm(bcrypt.MinCost + 1).NeedsRehash(hashed) {
+		t.Error("NeedsRehash() with higher cost should be true")
+	}
+}
+
+func TestPaswot_HashInputTooLong(t *testing.T) {
+	p := NewPaswotWithSaltAndPepper(strings.Repeat("s", 40), strings.Repeat("p", 40), NewHashOptionsBuilder().WithCost(bcrypt.MinCost).Build())
+	p.Plain = "password"
+
+	_, err := p.Hash()
+	var tooLong *InputTooLongError
+	if !errors.As(err, &tooLong) {
+		t.Fatalf("Hash() error = %v, want *InputTooLongError", err)
+	}
+	if tooLong.Length != 88 || tooLong.Limit != BcryptMaxInputLength {
+		t.Errorf("InputTooLongError = %+v, want Length 88 and Limit %d", tooLong, BcryptMaxInputLength)
+	}
+
+	p.Algorithm = NewBcryptSHA256Algorithm(bcrypt.MinCost)
+	hashed, err := p.Hash()
+	if err != nil {
+		t.Fatalf("Hash() with pre-hash error = %v", err)
+	}
+	if !p.Match(string(hashed)) {
+		t.Error("Match() with correct password should be true, but got false")
+	}
+
+	p.Plain = "wrongpassword"
+	if p.Match(string(hashed)) {
+		t.Error("Match() with incorrect password should be false, but got true")
+	}
+}
+
+func TestHashOptions_PreHash(t *testing.T) {
+	p := NewPaswot(NewHashOptionsBuilder().WithPreHash(true).WithCost(bcrypt.MinCost).Build())
+	algorithm, ok := p.Algorithm.(*BcryptSHA256Algorithm)
+	if !ok {
+		t.Fatalf("NewPaswot() with pre-hash Algorithm = %T, want *BcryptSHA256Algorithm", p.Algorithm)
+	}
+	if algorithm.Cost != bcrypt.MinCost {
+		t.Errorf("Algorithm.Cost = %d, want %d", algorithm.Cost, bcrypt.MinCost)
+	}
+}
@@ -0,0 +1,112 @@
+package paswot
+
+import (
+	"errors"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// HashOptions configures how a Paswot hashes. Algorithm takes precedence over
+// Cost, which selects bcrypt with the given cost. PreHash selects bcrypt-sha256
+// so inputs longer than bcrypt's 72-byte limit are hashed in full. NFKC
+// normalizes the password before hashing and verifying, so differently
+// encoded but equivalent input, such as a composed and a decomposed "é",
+// matches the same hash.
+type HashOptions struct {
+	Algorithm Algorithm
+	Cost      int
+	PreHash   bool
+	NFKC      bool
+}
+
+func (o *HashOptions) algorithm() Algorithm {
+	if o.Algorithm != nil {
+		return o.Algorithm
+	}
+	if o.PreHash {
+		return NewBcryptSHA256Algorithm(o.Cost)
+	}
+	if o.Cost != 0 {
+		return NewBcryptAlgorithm(o.Cost)
+	}
+	return nil
+}
+
+type HashOptionsBuilder struct {
+	HashOptions *HashOptions
+}
+
+func NewHashOptionsBuilder() *HashOptionsBuilder {
+	return &HashOptionsBuilder{HashOptions: &HashOptions{}}
+}
+
+func (builder *HashOptionsBuilder) WithAlgorithm(algorithm Algorithm) *HashOptionsBuilder {
+	builder.HashOptions.Algorithm = algorithm
+	return builder
+}
+
+func (builder *HashOptionsBuilder) WithCost(cost int) *HashOptionsBuilder {
+	builder.HashOptions.Cost = cost
+	return builder
+}
+
+func (builder *HashOptionsBuilder) WithPreHash(preHash bool) *HashOptionsBuilder {
+	builder.HashOptions.PreHash = preHash
+	return builder
+}
+
+func (builder *HashOptionsBuilder) WithNFKC(nfkc bool) *HashOptionsBuilder {
+	builder.HashOptions.NFKC = nfkc
+	return builder
+}
+
+func (builder *HashOptionsBuilder) Build() *HashOptions {
+	return builder.HashOptions
+}
+
+func applyHashOptions(p *Paswot, options []*HashOptions) *Paswot {
+	for _, o := range options {
+		if o == nil {
+			continue
+		}
+		if algorithm := o.algorithm(); algorithm != nil {
+			p.Algorithm = algorithm
+		}
+		if o.NFKC {
+			p.NFKC = true
+		}
+	}
+	return p
+}
+
+// CalibrateBcryptCost benchmarks bcrypt on the current host and returns the
+// highest cost whose hashing time stays under target. bcrypt.MinCost is
+// returned when even the cheapest cost exceeds target.
+func CalibrateBcryptCost(target time.Duration) (int, error) {
+	if target <= 0 {
+		return 0, errors.New("calibration target must be positive")
+	}
+
+	password := []byte("paswot-calibration")
+	best := bcrypt.MinCost
+	for cost := bcrypt.MinCost; cost <= bcrypt.MaxCost; cost++ {
+		start := time.Now()
+		if _, err := bcrypt.GenerateFromPassword(password, cost); err != nil {
+			return 0, err
+		}
+		elapsed := time.Since(start)
+		if elapsed > target {
+			break
+		}
+		best = cost
+
+		// Each increment doubles the work, so stop before a run that is
+		// certain to overshoot.
+		if elapsed*2 > target {
+			break
+		}
+	}
+
+	return best, nil
+}
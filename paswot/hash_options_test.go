@@ -0,0 +1,79 @@
+package paswot
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHashOptionsBuilder(t *testing.T) {
+	algorithm := NewArgon2idAlgorithm(1, 1024, 1)
+	options := NewHashOptionsBuilder().
+		WithAlgorithm(algorithm).
+		WithCost(12).
+		Build()
+
+	if options.Algorithm != algorithm {
+		t.Error("WithAlgorithm not set correctly")
+	}
+	if options.Cost != 12 {
+		t.Errorf("WithCost not set correctly. Got %d, want 12", options.Cost)
+	}
+}
+
+func TestNewPaswot_WithHashOptions(t *testing.T) {
+	t.Run("Cost", func(t *testing.T) {
+		p := NewPaswotWithSaltAndPepper("salt", "pepper", NewHashOptionsBuilder().WithCost(bcrypt.MinCost).Build())
+		p.Plain = "password"
+
+		hashed, err := p.Hash()
+		if err != nil {
+			t.Fatalf("Hash() error = %v", err)
+		}
+		cost, err := bcrypt.Cost(hashed)
+		if err != nil {
+			t.Fatalf("bcrypt.Cost() error = %v", err)
+		}
+		if cost != bcrypt.MinCost {
+			t.Errorf("Hash() cost = %d, want %d", cost, bcrypt.MinCost)
+		}
+	})
+
+	t.Run("Algorithm", func(t *testing.T) {
+		algorithm := NewPBKDF2Algorithm(1000)
+		p := NewPaswotWithSalt("salt", NewHashOptionsBuilder().WithAlgorithm(algorithm).WithCost(12).Build())
+		if p.Algorithm != algorithm {
+			t.Errorf("NewPaswotWithSalt().Algorithm = %v, want %v", p.Algorithm, algorithm)
+		}
+	})
+
+	t.Run("NoOptions", func(t *testing.T) {
+		p := NewPaswot(nil)
+		if p.Algorithm != nil {
+			t.Errorf("NewPaswot(nil).Algorithm = %v, want nil", p.Algorithm)
+		}
+	})
+}
+
+func TestCalibrateBcryptCost(t *testing.T) {
+	cost, err := CalibrateBcryptCost(time.Nanosecond)
+	if err != nil {
+		t.Fatalf("CalibrateBcryptCost() error = %v", err)
+	}
+	if cost != bcrypt.MinCost {
+		t.Errorf("CalibrateBcryptCost(1ns) = %d, want %d", cost, bcrypt.MinCost)
+	}
+
+	cost, err = CalibrateBcryptCost(50 * time.Millisecond)
+	if err != nil {
+		t.Fatalf("CalibrateBcryptCost() error = %v", err)
+	}
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		t.Errorf("CalibrateBcryptCost(50ms) = %d, out of range", cost)
+	}
+
+	if _, err := CalibrateBcryptCost(0); err == nil {
+		t.Error("CalibrateBcryptCost(0) should return an error")
+	}
+}
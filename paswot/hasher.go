@@ -1,21 +1,50 @@
 package paswot
 
-import (
-	"golang.org/x/crypto/bcrypt"
-)
+import "github.com/wissensalt/paswot/rule"
 
 type Hasher interface {
 	Hash() ([]byte, error)
 }
 
 func (p *Paswot) Hash() ([]byte, error) {
-	return bcrypt.GenerateFromPassword([]byte(p.Plain), bcrypt.DefaultCost)
+	return p.HashWith(p.algorithm())
 }
 
 func (p *WithSalt) Hash() ([]byte, error) {
-	return bcrypt.GenerateFromPassword([]byte(p.Plain+p.Salt), bcrypt.DefaultCost)
+	return p.HashWith(p.algorithm())
 }
 
 func (p *WithSaltAndPepper) Hash() ([]byte, error) {
-	return bcrypt.GenerateFromPassword([]byte(p.Plain+p.Salt+p.Pepper), bcrypt.DefaultCost)
+	return p.HashWith(p.algorithm())
+}
+
+// HashWith hashes with the given algorithm instead of the configured one.
+func (p *Paswot) HashWith(algorithm Algorithm) ([]byte, error) {
+	return algorithm.Hash([]byte(p.plain()))
+}
+
+func (p *WithSalt) HashWith(algorithm Algorithm) ([]byte, error) {
+	return algorithm.Hash([]byte(p.plain() + p.Salt))
+}
+
+func (p *WithSaltAndPepper) HashWith(algorithm Algorithm) ([]byte, error) {
+	if p.Keyring != nil {
+		return p.Keyring.hash(algorithm, []byte(p.plain()+p.Salt))
+	}
+	return algorithm.Hash([]byte(p.plain() + p.Salt + p.Pepper))
+}
+
+// plain returns Plain as it is hashed.
+func (p *Paswot) plain() string {
+	if p.NFKC {
+		return rule.NormalizeNFKC(p.Plain)
+	}
+	return p.Plain
+}
+
+func (p *Paswot) algorithm() Algorithm {
+	if p.Algorithm == nil {
+		return DefaultAlgorithm()
+	}
+	return p.Algorithm
 }
@@ -59,3 +59,33 @@ func TestPaswotWithSaltAndPepper_Hash(t *testing.T) {
 		t.Errorf("Hashed password does not match original: %v", err)
 	}
 }
+
+func TestPaswot_HashNFKC(t *testing.T) {
+	options := NewHashOptionsBuilder().WithCost(bcrypt.MinCost).WithNFKC(true).Build()
+	p := NewPaswotWithSalt("salt", options)
+	if !p.NFKC {
+		t.Fatal("NewPaswotWithSalt() did not apply the NFKC option")
+	}
+
+	// Decomposed "é"
+	p.Plain = "cafe\u0301"
+	hashed, err := p.Hash()
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword(hashed, []byte("caf\u00e9"+"salt")); err != nil {
+		t.Errorf("Hashed password was not normalized: %v", err)
+	}
+
+	composed := NewPaswotWithSalt("salt", options)
+	composed.Plain = "caf\u00e9"
+	if !composed.Match(string(hashed)) {
+		t.Error("Match() should accept the composed form")
+	}
+
+	p.NFKC = false
+	if p.Match(string(hashed)) {
+		t.Error("Match() without NFKC should reject the decomposed form")
+	}
+}
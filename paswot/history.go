@@ -0,0 +1,15 @@
+package paswot
+
+import (
+	"github.com/wissensalt/paswot/rule"
+)
+
+// NewHistoryRule returns a rule.HistoryRule comparing candidates with hashes
+// produced by Paswot.Hash. Salted or peppered histories need a
+// rule.HistoryRule whose NewMatcher builds the matching WithSalt or
+// WithSaltAndPepper.
+func NewHistoryRule(store rule.HistoryStore) *rule.HistoryRule {
+	return rule.NewHistoryRule(store, func(password string) rule.Matcher {
+		return &Paswot{Plain: password}
+	})
+}
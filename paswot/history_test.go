@@ -0,0 +1,39 @@
+package paswot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/wissensalt/paswot/rule"
+)
+
+func TestNewHistoryRule(t *testing.T) {
+	store := rule.NewMemoryHistoryStore(0)
+	for _, password := range []string{"Old#Secret1", "Old#Secret2"} {
+		p := NewPaswot(NewHashOptionsBuilder().WithCost(4).Build())
+		p.Plain = password
+		hashed, err := p.Hash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Add("user-1", string(hashed)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	paswotRule := rule.NewPaswotRuleBuilder().
+		WithLength(rule.NewLengthRule(8, 32)).
+		WithHistory(NewHistoryRule(store)).
+		Build()
+	ctx := &rule.ValidationContext{UserID: "user-1"}
+
+	p := &Paswot{Plain: "Old#Secret1"}
+	if valid, err := p.ValidateWithContext(paswotRule, ctx); valid || !errors.Is(err, rule.ErrPasswordReused) {
+		t.Errorf("ValidateWithContext(reused) = %v, %v, want false, ErrPasswordReused", valid, err)
+	}
+
+	p = &Paswot{Plain: "New#Secret3"}
+	if valid, err := p.ValidateWithContext(paswotRule, ctx); !valid || err != nil {
+		t.Errorf("ValidateWithContext(new) = %v, %v, want true, nil", valid, err)
+	}
+}
@@ -1,21 +1,103 @@
 package paswot
 
 import (
-	"golang.org/x/crypto/bcrypt"
+	"errors"
+	"fmt"
 )
 
 type Matcher interface {
 	Match(hashed string) bool
 }
 
+type Verifier interface {
+	Verify(hashed string) (*VerifyResult, error)
+}
+
+// VerifyResult describes a successfully parsed hash. NeedsRehash is relative
+// to the algorithm configured on the verifying Paswot.
+type VerifyResult struct {
+	Matched     bool
+	NeedsRehash bool
+	Algorithm   string
+}
+
+// MalformedHashError reports a stored hash that cannot be verified at all,
+// as opposed to a password that does not match it.
+type MalformedHashError struct {
+	Err error
+}
+
+func (e *MalformedHashError) Error() string {
+	return fmt.Sprintf("malformed hash: %v", e.Err)
+}
+
+func (e *MalformedHashError) Unwrap() error {
+	return e.Err
+}
+
+// Match dispatches on the algorithm identifier embedded in hashed, so a
+// Paswot configured with one algorithm still verifies hashes produced by
+// any other registered algorithm.
 func (p *Paswot) Match(hashed string) bool {
-	return bcrypt.CompareHashAndPassword([]byte(hashed), []byte(p.Plain)) == nil
+	result, err := p.Verify(hashed)
+	return err == nil && result.Matched
 }
 
 func (p *WithSalt) Match(hashed string) bool {
-	return bcrypt.CompareHashAndPassword([]byte(hashed), []byte(p.Plain+p.Salt)) == nil
+	result, err := p.Verify(hashed)
+	return err == nil && result.Matched
 }
 
 func (p *WithSaltAndPepper) Match(hashed string) bool {
-	return bcrypt.CompareHashAndPassword([]byte(hashed), []byte(p.Plain+p.Salt+p.Pepper)) == nil
+	result, err := p.Verify(hashed)
+	return err == nil && result.Matched
+}
+
+func (p *Paswot) Verify(hashed string) (*VerifyResult, error) {
+	err := compare(hashed, []byte(p.plain()))
+	return verifyResult(hashed, p.algorithm(), err)
+}
+
+func (p *WithSalt) Verify(hashed string) (*VerifyResult, error) {
+	err := compare(hashed, []byte(p.plain()+p.Salt))
+	return verifyResult(hashed, p.algorithm(), err)
+}
+
+func (p *WithSaltAndPepper) Verify(hashed string) (*VerifyResult, error) {
+	if p.Keyring == nil {
+		err := compare(hashed, []byte(p.plain()+p.Salt+p.Pepper))
+		return verifyResult(hashed, p.algorithm(), err)
+	}
+
+	err := p.Keyring.compare(hashed, []byte(p.plain()+p.Salt))
+	result, err := verifyResult(hashed, p.algorithm(), err)
+	if result != nil && p.Keyring.NeedsRotation(hashed) {
+		result.NeedsRehash = true
+	}
+	return result, err
+}
+
+func verifyResult(hashed string, configured Algorithm, err error) (*VerifyResult, error) {
+	var tooLong *InputTooLongError
+	switch {
+	case err == nil, errors.Is(err, ErrMismatchedHashAndPassword):
+	case errors.As(err, &tooLong), errors.Is(err, ErrUnknownPepperKey):
+		return nil, err
+	default:
+		return nil, &MalformedHashError{Err: err}
+	}
+
+	if _, inner, ok := splitPeppered(hashed); ok {
+		hashed = inner
+	}
+	algorithm, algorithmErr := algorithmFor(hashed)
+	if algorithmErr != nil {
+		return nil, &MalformedHashError{Err: algorithmErr}
+	}
+
+	return &VerifyResult{
+		Matched:     err == nil,
+		NeedsRehash: NeedsRehash(hashed, NewHashPolicy(configured)),
+		Algorithm:   algorithm.Name(),
+	}, nil
 }
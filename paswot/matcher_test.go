@@ -1,7 +1,10 @@
 package paswot
 
 import (
+	"errors"
 	"testing"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestPaswot_Match(t *testing.T) {
@@ -78,3 +81,71 @@ func TestPaswotWithSaltAndPepper_Match(t *testing.T) {
 		t.Errorf("Match() with incorrect password should be false, but got true")
 	}
 }
+
+func TestPaswot_Verify(t *testing.T) {
+	p := NewPaswot(NewHashOptionsBuilder().WithCost(bcrypt.MinCost).Build())
+	p.Plain = "password"
+
+	hashed, err := p.Hash()
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	t.Run("Matched", func(t *testing.T) {
+		result, err := p.Verify(string(hashed))
+		if err != nil {
+			t.Fatalf("Verify() error = %v", err)
+		}
+		if !result.Matched || result.NeedsRehash || result.Algorithm != "bcrypt" {
+			t.Errorf("Verify() = %+v, want matched bcrypt hash without rehash", result)
+		}
+	})
+
+	t.Run("NotMatched", func(t *testing.T) {
+		wrong := &Paswot{Plain: "wrongpassword"}
+		result, err := wrong.Verify(string(hashed))
+		if err != nil {
+			t.Fatalf("Verify() error = %v", err)
+		}
+		if result.Matched {
+			t.Error("Verify() with incorrect password should not match")
+		}
+		// The default cost is higher than the hash's
+		if !result.NeedsRehash {
+			t.Error("Verify() should report that the hash needs a rehash")
+		}
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			hashed  string
+			wantErr error
+		}{
+			{name: "Empty", hashed: "", wantErr: ErrUnknownAlgorithm},
+			{name: "Unknown algorithm", hashed: "$md5$abc$def", wantErr: ErrUnknownAlgorithm},
+			{name: "Too short", hashed: "$2a$04$abc", wantErr: bcrypt.ErrHashTooShort},
+			{name: "Invalid PHC", hashed: "$argon2id$v=19$m=1024$c2FsdA$aGFzaA", wantErr: ErrInvalidPHC},
+			{name: "Argon2id memory too high", hashed: "$argon2id$v=19$m=4294967295,t=1,p=1$c2FsdA$aGFzaA", wantErr: ErrInvalidPHC},
+			{name: "Argon2id time too high", hashed: "$argon2id$v=19$m=1024,t=1000,p=1$c2FsdA$aGFzaA", wantErr: ErrInvalidPHC},
+			{name: "Argon2id threads too high", hashed: "$argon2id$v=19$m=1024,t=1,p=255$c2FsdA$aGFzaA", wantErr: ErrInvalidPHC},
+			{name: "Scrypt ln too high", hashed: "$scrypt$ln=40,r=8,p=1$c2FsdA$aGFzaA", wantErr: ErrInvalidPHC},
+			{name: "Scrypt memory too high", hashed: "$scrypt$ln=20,r=16,p=1$c2FsdA$aGFzaA", wantErr: ErrInvalidPHC},
+			{name: "Scrypt r·p too high", hashed: "$scrypt$ln=4,r=64,p=64$c2FsdA$aGFzaA", wantErr: ErrInvalidPHC},
+			{name: "PBKDF2 iterations too high", hashed: "$pbkdf2-sha256$i=2147483647$c2FsdA$aGFzaA", wantErr: ErrInvalidPHC},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				result, err := p.Verify(tc.hashed)
+				var malformed *MalformedHashError
+				if !errors.As(err, &malformed) {
+					t.Fatalf("Verify(%q) = %+v, %v; want *MalformedHashError", tc.hashed, result, err)
+				}
+				if !errors.Is(err, tc.wantErr) {
+					t.Errorf("Verify(%q) error = %v, want %v", tc.hashed, err, tc.wantErr)
+				}
+			})
+		}
+	})
+}
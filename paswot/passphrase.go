@@ -0,0 +1,119 @@
+package paswot
+
+import (
+	"crypto/rand"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/wissensalt/paswot/rule"
+)
+
+// GeneratePassphrase generates a passphrase satisfying passphrase alone, see
+// Generate for passphrases that must satisfy further rules.
+func (p *Paswot) GeneratePassphrase(passphrase *rule.PassphraseRule) error {
+	return p.Generate(rule.NewPaswotRuleBuilder().WithPassphrase(passphrase).Build())
+}
+
+func passphraseRuleOf(pasRule *rule.PaswotRule) *rule.PassphraseRule {
+	for _, r := range pasRule.Rules {
+		if passphrase, ok := r.(*rule.PassphraseRule); ok {
+			return passphrase
+		}
+	}
+	return nil
+}
+
+// generatePassphrase draws a passphrase candidate. Digits and symbols are
+// raised to the minimums of character, and words are capitalized when it
+// requires uppercase letters. The entropy ignores where digits and symbols
+// are placed.
+func generatePassphrase(passphrase *rule.PassphraseRule, character *rule.CharacterRule, excluded string) (string, float64, error) {
+	digitCount, symbolCount := passphrase.Digits, passphrase.Symbols
+	capitalization := passphrase.Capitalization
+	if character != nil {
+		digitCount = max(digitCount, character.MinNumber)
+		symbolCount = max(symbolCount, character.MinSymbol)
+		if character.MinUppercase > 0 && capitalization == rule.CapitalizeNone {
+			capitalization = rule.CapitalizeEach
+		}
+	}
+
+	list := passphrase.List()
+	if len(list) == 0 {
+		return "", 0, errors.New("passphrase wordlist is empty")
+	}
+
+	words := make([]string, passphrase.Words)
+	for i := range words {
+		n, err := randomInt(len(list))
+		if err != nil {
+			return "", 0, err
+		}
+		words[i] = list[n]
+	}
+	entropy := float64(len(words)) * math.Log2(float64(len(list)))
+
+	for i, word := range words {
+		capitalize := capitalization == rule.CapitalizeEach
+		if capitalization == rule.CapitalizeRandom {
+			n, err := randomInt(2)
+			if err != nil {
+				return "", 0, err
+			}
+			capitalize = n == 1
+			entropy++
+		}
+		if capitalize {
+			words[i] = capitalizeWord(word)
+		}
+	}
+
+	symbolSet := rule.Symbol
+	if character != nil {
+		symbolSet = character.SymbolSet()
+	}
+	digits := withoutChars(rule.Number, excluded)
+	symbols := withoutChars(symbolSet, excluded+passphrase.Separator)
+	for _, extra := range []struct {
+		count   int
+		charset []rune
+	}{{digitCount, digits}, {symbolCount, symbols}} {
+		if extra.count == 0 {
+			continue
+		}
+		if len(extra.charset) == 0 {
+			return "", 0, errors.New("no characters left to add to the passphrase")
+		}
+		for i := 0; i < extra.count; i++ {
+			char, err := getRandomChar(extra.charset)
+			if err != nil {
+				return "", 0, err
+			}
+			n, err := randomInt(len(words))
+			if err != nil {
+				return "", 0, err
+			}
+			words[n] += string(char)
+		}
+		entropy += rule.CharsetEntropy(rule.Charset(extra.charset), extra.count)
+	}
+
+	return strings.Join(words, passphrase.Separator), entropy, nil
+}
+
+func capitalizeWord(word string) string {
+	first, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(first)) + word[size:]
+}
+
+func randomInt(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(i.Int64()), nil
+}
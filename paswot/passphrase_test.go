@@ -0,0 +1,114 @@
+package paswot
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/wissensalt/paswot/rule"
+)
+
+func TestPaswot_GeneratePassphrase(t *testing.T) {
+	p := NewPaswot()
+	if err := p.GeneratePassphrase(rule.NewPassphraseRule(5)); err != nil {
+		t.Fatalf("GeneratePassphrase() error = %v", err)
+	}
+
+	words := rule.NewPassphraseRule(5).SplitWords(p.Plain)
+	if len(words) != 5 {
+		t.Fatalf("GeneratePassphrase() = %q, want 5 words", p.Plain)
+	}
+	wordlist := map[string]bool{}
+	for _, word := range rule.EFFLargeWordlist() {
+		wordlist[word] = true
+	}
+	for _, word := range words {
+		if !wordlist[word] {
+			t.Errorf("GeneratePassphrase() word %q is not in the EFF wordlist", word)
+		}
+	}
+
+	want := 5 * math.Log2(7776)
+	if math.Abs(p.Entropy-want) > 1e-9 {
+		t.Errorf("Entropy = %f, want %f", p.Entropy, want)
+	}
+}
+
+func TestPaswot_GeneratePassphraseOptions(t *testing.T) {
+	passphrase := rule.NewPassphraseRuleBuilder().
+		WithWords(3).
+		WithSeparator(" ").
+		WithCapitalization(rule.CapitalizeEach).
+		WithDigits(2).
+		WithSymbols(1).
+		WithWordlist([]string{"alpha", "bravo", "charlie", "delta"}).
+		Build()
+
+	p := NewPaswot()
+	if err := p.GeneratePassphrase(passphrase); err != nil {
+		t.Fatalf("GeneratePassphrase() error = %v", err)
+	}
+
+	var upper, digits, symbols int
+	for _, char := range p.Plain {
+		switch {
+		case unicode.IsUpper(char):
+			upper++
+		case strings.ContainsRune(string(rule.Number), char):
+			digits++
+		case strings.ContainsRune(string(rule.Symbol), char):
+			symbols++
+		}
+	}
+	if upper != 3 || digits != 2 || symbols != 1 {
+		t.Errorf("GeneratePassphrase() = %q, want 3 uppercase, 2 digits and 1 symbol", p.Plain)
+	}
+	if len(strings.Fields(p.Plain)) != 3 {
+		t.Errorf("GeneratePassphrase() = %q, want 3 space separated words", p.Plain)
+	}
+
+	want := 3*2 + 2*math.Log2(10) + math.Log2(float64(len(rule.Symbol)))
+	if math.Abs(p.Entropy-want) > 1e-9 {
+		t.Errorf("Entropy = %f, want %f", p.Entropy, want)
+	}
+}
+
+func TestPaswot_GeneratePassphraseWithRule(t *testing.T) {
+	pasRule := rule.NewPaswotRuleBuilder().
+		WithLength(rule.NewLengthRule(20, 64)).
+		WithCharacter(rule.NewCharacterRule(1, 1, 1, 1)).
+		WithNoWhitespace(rule.NewNoWhitespaceRule()).
+		WithPassphrase(rule.NewPassphraseRule(4)).
+		Build()
+
+	for i := 0; i < 20; i++ {
+		p := NewPaswot()
+		if err := p.Generate(pasRule); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if _, err := p.Validate(pasRule); err != nil {
+			t.Errorf("Generate() = %q, which fails validation: %v", p.Plain, err)
+		}
+	}
+
+	invalid := rule.NewPaswotRuleBuilder().WithPassphrase(&rule.PassphraseRule{Words: 4}).Build()
+	if err := NewPaswot().Generate(invalid); err == nil {
+		t.Error("Generate() with an invalid passphrase rule should return an error")
+	}
+}
+
+func TestPaswot_GeneratePassphraseRepeatedWords(t *testing.T) {
+	passphrase := rule.NewPassphraseRuleBuilder().
+		WithWords(4).
+		WithWordlist([]string{"alpha", "bravo", "alpha", "bravo", "alpha"}).
+		Build()
+
+	p := NewPaswot()
+	if err := p.GeneratePassphrase(passphrase); err != nil {
+		t.Fatalf("GeneratePassphrase() error = %v", err)
+	}
+	if want := 4.0; math.Abs(p.Entropy-want) > 1e-9 {
+		t.Errorf("Entropy = %f, want %f for 2 distinct words", p.Entropy, want)
+	}
+}
@@ -3,17 +3,26 @@ package paswot
 import (
 	"crypto/rand"
 	"errors"
+	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/wissensalt/paswot/rule"
 )
 
+// Paswot holds a plain password. Entropy is set by Generate to the bits of
+// entropy of the generator that produced Plain, a lower bound that ignores
+// the extra randomness of shuffling. When NFKC is set, Plain is NFKC
+// normalized before it is hashed or verified.
 type Paswot struct {
-	Plain string
+	Plain     string
+	Algorithm Algorithm
+	Entropy   float64
+	NFKC      bool
 }
 
-func NewPaswot() *Paswot {
-	return &Paswot{}
+func NewPaswot(options ...*HashOptions) *Paswot {
+	return applyHashOptions(&Paswot{}, options)
 }
 
 type WithSalt struct {
@@ -21,19 +30,32 @@ type WithSalt struct {
 	Salt string
 }
 
-func NewPaswotWithSalt(salt string) *WithSalt {
-	return &WithSalt{Paswot: &Paswot{}, Salt: salt}
+func NewPaswotWithSalt(salt string, options ...*HashOptions) *WithSalt {
+	return &WithSalt{Paswot: applyHashOptions(&Paswot{}, options), Salt: salt}
 }
 
+// WithSaltAndPepper appends Pepper to the hash input, or, when Keyring is
+// set, applies the keyring's active pepper with HMAC instead.
 type WithSaltAndPepper struct {
 	*WithSalt
-	Pepper string
+	Pepper  string
+	Keyring *PepperKeyring
 }
 
-func NewPaswotWithSaltAndPepper(salt, pepper string) *WithSaltAndPepper {
-	return &WithSaltAndPepper{WithSalt: &WithSalt{Paswot: &Paswot{}, Salt: salt}, Pepper: pepper}
+func NewPaswotWithSaltAndPepper(salt, pepper string, options ...*HashOptions) *WithSaltAndPepper {
+	return &WithSaltAndPepper{WithSalt: NewPaswotWithSalt(salt, options...), Pepper: pepper}
 }
 
+func NewPaswotWithSaltAndKeyring(salt string, keyring *PepperKeyring, options ...*HashOptions) *WithSaltAndPepper {
+	return &WithSaltAndPepper{WithSalt: NewPaswotWithSalt(salt, options...), Keyring: keyring}
+}
+
+// maxGenerateAttempts bounds how many candidates Generate draws when custom
+// rules reject candidates that satisfy the generation hints.
+const maxGenerateAttempts = 100
+
+// Generate sets Plain to a random password satisfying pasRule, or to a
+// passphrase when pasRule has a rule.PassphraseRule.
 func (p *Paswot) Generate(pasRule *rule.PaswotRule) error {
 	if pasRule == nil {
 		pasRule = rule.DefaultRule()
@@ -44,89 +66,114 @@ func (p *Paswot) Generate(pasRule *rule.PaswotRule) error {
 		return err
 	}
 
-	var passwordChars []rune
-	var availableChars string
-
-	// Uppercase
-	if pasRule.Character.MinUppercase > 0 {
-		availableChars += string(rule.AlphabetUpperCase)
-		for i := 0; i < pasRule.Character.MinUppercase; i++ {
-			char, err := getRandomChar(string(rule.AlphabetUpperCase))
-			if err != nil {
-				return err
-			}
-			passwordChars = append(passwordChars, char)
+	passphrase := passphraseRuleOf(pasRule)
+	if passphrase != nil {
+		if _, err := passphrase.IsValid(); err != nil {
+			return err
 		}
 	}
 
-	// Lowercase
-	if pasRule.Character.MinLowercase > 0 {
-		availableChars += string(rule.AlphabetLowerCase)
-		for i := 0; i < pasRule.Character.MinLowercase; i++ {
-			char, err := getRandomChar(string(rule.AlphabetLowerCase))
-			if err != nil {
-				return err
-			}
-			passwordChars = append(passwordChars, char)
+	hint := pasRule.GenerationHint()
+	for attempt := 0; attempt < maxGenerateAttempts; attempt++ {
+		var plain string
+		var entropy float64
+		if passphrase != nil {
+			plain, entropy, err = generatePassphrase(passphrase, pasRule.Character, hint.Excluded)
+		} else {
+			plain, entropy, err = generateFromHint(hint)
+		}
+		if err != nil {
+			return err
 		}
-	}
 
-	// Numbers
-	if pasRule.Character.MinNumber > 0 {
-		availableChars += string(rule.Number)
-		for i := 0; i < pasRule.Character.MinNumber; i++ {
-			char, err := getRandomChar(string(rule.Number))
-			if err != nil {
-				return err
-			}
-			passwordChars = append(passwordChars, char)
+		candidate := &Paswot{Plain: plain}
+		if _, err := candidate.Validate(pasRule); err == nil {
+			p.Plain = plain
+			p.Entropy = entropy
+			return nil
 		}
 	}
 
-	// Symbols
-	if pasRule.Character.MinSymbol > 0 {
-		availableChars += string(rule.Symbol)
-		for i := 0; i < pasRule.Character.MinSymbol; i++ {
-			char, err := getRandomChar(string(rule.Symbol))
+	return errors.New("could not generate a password satisfying all rules")
+}
+
+func generateFromHint(hint rule.GenerationHint) (string, float64, error) {
+	var passwordChars []rune
+	var availableChars []rune
+	var entropy float64
+
+	// Required characters, e.g. the minimum uppercase, lowercase, number
+	// and symbol counts of the character rule
+	for _, requirement := range hint.Required {
+		charset := withoutChars(requirement.Charset, hint.Excluded)
+		if len(charset) == 0 {
+			return "", 0, fmt.Errorf("no characters left in required charset %q", requirement.Charset)
+		}
+		availableChars = append(availableChars, charset...)
+		for i := 0; i < requirement.Min; i++ {
+			char, err := getRandomChar(charset)
 			if err != nil {
-				return err
+				return "", 0, err
 			}
 			passwordChars = append(passwordChars, char)
 		}
+		entropy += rule.CharsetEntropy(rule.Charset(charset), requirement.Min)
 	}
 
-	// If no character rules are set, use all characters.
-	if availableChars == "" {
-		availableChars = string(rule.All)
+	// If no characters are required, use all characters.
+	if len(availableChars) == 0 {
+		availableChars = withoutChars(rule.All, hint.Excluded)
 	}
+	availableChars = uniqueChars(availableChars)
 
 	// Fill the remaining length
-	remainingLen := pasRule.Length.Min - len(passwordChars)
+	remainingLen := hint.MinLength - len(passwordChars)
 	for i := 0; i < remainingLen; i++ {
 		char, err := getRandomChar(availableChars)
 		if err != nil {
-			return err
+			return "", 0, err
 		}
 		passwordChars = append(passwordChars, char)
 	}
+	entropy += rule.CharsetEntropy(rule.Charset(availableChars), remainingLen)
 
 	// Shuffle the password
 	shuffled, err := shuffle(passwordChars)
 	if err != nil {
-		return err
+		return "", 0, err
 	}
 
-	p.Plain = string(shuffled)
+	return string(shuffled), entropy, nil
+}
+
+func withoutChars(charset rule.Charset, excluded string) []rune {
+	var chars []rune
+	for _, char := range string(charset) {
+		if !strings.ContainsRune(excluded, char) {
+			chars = append(chars, char)
+		}
+	}
+	return chars
+}
 
-	return nil
+func uniqueChars(chars []rune) []rune {
+	seen := map[rune]bool{}
+	var unique []rune
+	for _, char := range chars {
+		if !seen[char] {
+			seen[char] = true
+			unique = append(unique, char)
+		}
+	}
+	return unique
 }
 
-func getRandomChar(charset string) (rune, error) {
+func getRandomChar(charset []rune) (rune, error) {
 	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
 	if err != nil {
 		return 0, err
 	}
-	return rune(charset[n.Int64()]), nil
+	return charset[n.Int64()], nil
 }
 
 func shuffle(slice []rune) ([]rune, error) {
@@ -143,37 +190,42 @@ func shuffle(slice []rune) ([]rune, error) {
 }
 
 func (p *Paswot) Validate(paswotRule *rule.PaswotRule) (bool, error) {
+	return p.ValidateWithContext(paswotRule, nil)
+}
+
+// ValidateWithContext is Validate for rules that depend on the account the
+// password belongs to, such as rule.UserAttributeRule.
+func (p *Paswot) ValidateWithContext(paswotRule *rule.PaswotRule, ctx *rule.ValidationContext) (bool, error) {
 	if paswotRule == nil {
 		paswotRule = rule.DefaultRule()
 	}
 
-	if p.Plain == "" {
-		return false, errors.New("password cannot be empty")
+	plain := paswotRule.Normalize(p.Plain)
+	if plain == "" {
+		return false, rule.EmptyViolation()
 	}
 
-	// No Whitespace Rule
-	if paswotRule.NoWhitespace != nil {
-		_, err := paswotRule.NoWhitespace.Validate(p.Plain)
+	// Built-in rules first, then custom rules in the order they were added
+	for _, r := range paswotRule.All() {
+		_, err := rule.ValidateRule(r, plain, ctx)
 		if err != nil {
 			return false, err
 		}
 	}
 
-	// Length Rule
-	if paswotRule.Length != nil {
-		_, err := paswotRule.Length.Validate(p.Plain)
-		if err != nil {
-			return false, err
-		}
-	}
+	return true, nil
+}
 
-	// Character Rule
-	if paswotRule.Character != nil {
-		_, err := paswotRule.Character.Validate(p.Plain)
-		if err != nil {
-			return false, err
-		}
+// ValidateAll reports every rule the password violates, unlike Validate which
+// stops at the first one.
+func (p *Paswot) ValidateAll(paswotRule *rule.PaswotRule) *rule.ValidationReport {
+	return p.ValidateAllWithContext(paswotRule, nil)
+}
+
+func (p *Paswot) ValidateAllWithContext(paswotRule *rule.PaswotRule, ctx *rule.ValidationContext) *rule.ValidationReport {
+	if paswotRule == nil {
+		paswotRule = rule.DefaultRule()
 	}
 
-	return true, nil
+	return paswotRule.ReportWithContext(p.Plain, ctx)
 }
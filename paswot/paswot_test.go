@@ -1,8 +1,12 @@
 package paswot
 
 import (
+	"errors"
+	"math"
+	"reflect"
 	"strings"
 	"testing"
+	"unicode"
 
 	"github.com/wissensalt/paswot/rule"
 )
@@ -132,6 +136,9 @@ func TestPaswot_Validate(t *testing.T) {
 		if err.Error() != "password cannot be empty" {
 			t.Errorf("Unexpected error message: got '%s'", err.Error())
 		}
+		if !errors.Is(err, rule.ErrEmpty) {
+			t.Errorf("Validate() error = %v, want %v", err, rule.ErrEmpty)
+		}
 	})
 
 	t.Run("WhitespaceNotAllowed", func(t *testing.T) {
@@ -167,3 +174,173 @@ func TestPaswot_Validate(t *testing.T) {
 		}
 	})
 }
+
+func TestPaswot_ValidateAll(t *testing.T) {
+	p := &Paswot{Plain: "validpassword"}
+	report := p.ValidateAll(nil)
+	if report.Valid() {
+		t.Fatal("ValidateAll() should report violations")
+	}
+
+	want := []rule.Code{rule.CodeMissingUppercase, rule.CodeMissingNumber, rule.CodeMissingSymbol}
+	if !reflect.DeepEqual(report.Codes(), want) {
+		t.Errorf("ValidateAll() codes = %v, want %v", report.Codes(), want)
+	}
+
+	p.Plain = "Valid123!"
+	if report := p.ValidateAll(nil); !report.Valid() {
+		t.Errorf("ValidateAll() should be valid, got violations %v", report.Codes())
+	}
+}
+
+type noDigitRule struct {
+	digit rune
+}
+
+func (r noDigitRule) Name() string {
+	return "no_digit"
+}
+
+func (r noDigitRule) Validate(password string) (bool, error) {
+	if strings.ContainsRune(password, r.digit) {
+		return false, errors.New("password cannot contain " + string(r.digit))
+	}
+	return true, nil
+}
+
+func (r noDigitRule) GenerationHint() rule.GenerationHint {
+	return rule.GenerationHint{Excluded: string(r.digit)}
+}
+
+// startsWithLetterRule gives no generation hint, so Generate has to retry
+// until a candidate happens to satisfy it.
+type startsWithLetterRule struct{}
+
+func (r startsWithLetterRule) Name() string {
+	return "starts_with_letter"
+}
+
+func (r startsWithLetterRule) Validate(password string) (bool, error) {
+	if !strings.ContainsAny(password[:1], string(rule.AlphabetUpperCase+rule.AlphabetLowerCase)) {
+		return false, errors.New("password must start with a letter")
+	}
+	return true, nil
+}
+
+func TestPaswot_CustomRules(t *testing.T) {
+	paswotRule := rule.NewPaswotRuleBuilder().
+		WithLength(rule.NewLengthRule(12, 16)).
+		WithCharacter(rule.NewCharacterRule(1, 1, 4, 1)).
+		WithRule(noDigitRule{digit: '7'}).
+		WithRule(startsWithLetterRule{}).
+		Build()
+
+	for i := 0; i < 20; i++ {
+		p := NewPaswot()
+		if err := p.Generate(paswotRule); err != nil {
+			t.Fatalf("Generate() with custom rules failed: %v", err)
+		}
+		if valid, err := p.Validate(paswotRule); !valid || err != nil {
+			t.Fatalf("Generated password %q is not valid: %v", p.Plain, err)
+		}
+	}
+
+	p := &Paswot{Plain: "Abc7!defgh123"}
+	if _, err := p.Validate(paswotRule); err == nil || err.Error() != "password cannot contain 7" {
+		t.Errorf("Validate() error = %v, want the custom rule's error", err)
+	}
+}
+
+func TestPaswot_GenerateEntropy(t *testing.T) {
+	p := NewPaswot()
+	paswotRule := rule.NewPaswotRuleBuilder().
+		WithLength(rule.NewLengthRule(16, 20)).
+		WithCharacter(rule.NewCharacterRule(1, 1, 1, 1)).
+		WithMinEntropy(rule.NewMinEntropyRule(80)).
+		Build()
+
+	if err := p.Generate(paswotRule); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	upper, lower, number, symbol := float64(len(rule.AlphabetUpperCase)), float64(len(rule.AlphabetLowerCase)), float64(len(rule.Number)), float64(len(rule.Symbol))
+	want := math.Log2(upper) + math.Log2(lower) + math.Log2(number) + math.Log2(symbol) +
+		12*math.Log2(upper+lower+number+symbol)
+	if math.Abs(p.Entropy-want) > 1e-9 {
+		t.Errorf("Entropy = %f, want %f", p.Entropy, want)
+	}
+	if p.Entropy < 80 {
+		t.Errorf("Entropy = %f, want at least 80 bits", p.Entropy)
+	}
+}
+
+func TestPaswot_ValidateWithContext(t *testing.T) {
+	paswotRule := rule.NewPaswotRuleBuilder().
+		WithLength(rule.NewLengthRule(8, 32)).
+		WithUserAttribute(rule.NewUserAttributeRule()).
+		Build()
+	ctx := &rule.ValidationContext{Username: "alice", Email: "alice.liddell@example.com"}
+
+	p := &Paswot{Plain: "Liddell#Wonder9"}
+	if valid, err := p.Validate(paswotRule); !valid || err != nil {
+		t.Fatalf("Validate() = %v, %v, want true, nil", valid, err)
+	}
+	if valid, err := p.ValidateWithContext(paswotRule, ctx); valid || !errors.Is(err, rule.ErrContainsUserAttribute) {
+		t.Errorf("ValidateWithContext() = %v, %v, want false, ErrContainsUserAttribute", valid, err)
+	}
+	if report := p.ValidateAllWithContext(paswotRule, ctx); report.Valid() {
+		t.Error("ValidateAllWithContext() should report the user attribute")
+	}
+}
+
+func TestPaswot_GenerateCharsets(t *testing.T) {
+	paswotRule := rule.NewPaswotRuleBuilder().
+		WithLength(rule.NewLengthRule(16, 16)).
+		WithCharacter(rule.NewCharacterRuleBuilder().
+			WithMinUppercase(2).
+			WithMinLowercase(2).
+			WithMinNumber(2).
+			WithMinSymbol(2).
+			WithAllowedSymbols("!#%").
+			WithExcluded("xyz").
+			WithExcludeAmbiguous(true).
+			WithClass("hex letter", "ABCDEF", 1).
+			Build()).
+		WithNoWhitespace(rule.NewNoWhitespaceRule()).
+		Build()
+
+	for i := 0; i < 50; i++ {
+		p := NewPaswot()
+		if err := p.Generate(paswotRule); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		allowed := func(char rune) bool {
+			return strings.ContainsRune(string(rule.AlphabetUpperCase+rule.AlphabetLowerCase+rule.Number)+"!#%", char) &&
+				!strings.ContainsRune("xyz0O1lI", char)
+		}
+		if strings.IndexFunc(p.Plain, func(char rune) bool { return !allowed(char) }) >= 0 {
+			t.Fatalf("Generate() = %q, contains characters the rule does not allow", p.Plain)
+		}
+		if _, err := p.Validate(paswotRule); err != nil {
+			t.Fatalf("Generate() = %q, which fails validation: %v", p.Plain, err)
+		}
+	}
+}
+
+func TestPaswot_GenerateAllowedChars(t *testing.T) {
+	paswotRule := rule.NewPaswotRuleBuilder().
+		WithLength(rule.NewLengthRule(12, 12)).
+		WithCharacter(rule.NewCharacterRule(1, 1, 1, 1)).
+		WithAllowedChars(rule.NewAllowedCharsRule("!?", unicode.Letter, unicode.Nd)).
+		Build()
+
+	for i := 0; i < 20; i++ {
+		p := NewPaswot()
+		if err := p.Generate(paswotRule); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if _, err := p.Validate(paswotRule); err != nil {
+			t.Fatalf("Generate() = %q, which fails validation: %v", p.Plain, err)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package paswot
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wissensalt/paswot/rule"
+)
+
+var ErrInvalidPattern = errors.New("invalid pattern")
+
+// maxPatternLength bounds the length a pattern may generate.
+const maxPatternLength = 1024
+
+// patternClasses are the shorthand classes of a pattern.
+var patternClasses = map[rune]rule.Charset{
+	'A': rule.AlphabetUpperCase,
+	'a': rule.AlphabetLowerCase,
+	'9': rule.Number,
+	'#': rule.Symbol,
+	'*': rule.All,
+}
+
+// Pattern generates passwords of a fixed format. Each position of a
+// generated password is drawn independently and uniformly from the
+// characters allowed at that position.
+type Pattern struct {
+	source    string
+	positions [][]rune
+}
+
+// CompilePattern parses a pattern made of
+//
+//	A       an uppercase letter
+//	a       a lowercase letter
+//	9       a digit
+//	#       a symbol of rule.Symbol
+//	*       any character of rule.All
+//	[...]   one of the listed characters, with ranges such as [A-Z0-9]
+//	{n}     n times the preceding character or class
+//	\c      the character c itself
+//
+// Any other character stands for itself, so "Aaaa-9999-aaaa" and
+// "[A-Z]{4}-[0-9]{4}" are both valid patterns.
+func CompilePattern(pattern string) (*Pattern, error) {
+	compiled := &Pattern{source: pattern}
+	runes := []rune(pattern)
+	quantifiable := false
+	for i := 0; i < len(runes); i++ {
+		switch char := runes[i]; char {
+		case '\\':
+			if i+1 == len(runes) {
+				return nil, patternError(pattern, i, "trailing escape")
+			}
+			i++
+			compiled.positions = append(compiled.positions, []rune{runes[i]})
+			quantifiable = true
+		case '[':
+			class, end, err := parsePatternClass(pattern, runes, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+			compiled.positions = append(compiled.positions, class)
+			quantifiable = true
+		case '{':
+			if !quantifiable {
+				return nil, patternError(pattern, i, "repetition without a preceding character")
+			}
+			end := slices.Index(runes[i:], '}')
+			if end < 0 {
+				return nil, patternError(pattern, i, "unterminated repetition")
+			}
+			count, err := strconv.Atoi(string(runes[i+1 : i+end]))
+			if err != nil || count < 1 || count > maxPatternLength {
+				return nil, patternError(pattern, i, "repetition must be a number between 1 and "+strconv.Itoa(maxPatternLength))
+			}
+			last := compiled.positions[len(compiled.positions)-1]
+			for j := 1; j < count && len(compiled.positions) <= maxPatternLength; j++ {
+				compiled.positions = append(compiled.positions, last)
+			}
+			i += end
+			quantifiable = false
+		case ']', '}':
+			return nil, patternError(pattern, i, "unescaped "+string(char))
+		default:
+			if charset, ok := patternClasses[char]; ok {
+				compiled.positions = append(compiled.positions, []rune(string(charset)))
+			} else {
+				compiled.positions = append(compiled.positions, []rune{char})
+			}
+			quantifiable = true
+		}
+
+		if len(compiled.positions) > maxPatternLength {
+			return nil, patternError(pattern, i, "pattern longer than "+strconv.Itoa(maxPatternLength)+" characters")
+		}
+	}
+
+	if len(compiled.positions) == 0 {
+		return nil, fmt.Errorf("%w: empty pattern", ErrInvalidPattern)
+	}
+	return compiled, nil
+}
+
+func MustCompilePattern(pattern string) *Pattern {
+	compiled, err := CompilePattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return compiled
+}
+
+// parsePatternClass parses the class starting at runes[start] and returns its
+// characters and the index of the closing bracket.
+func parsePatternClass(pattern string, runes []rune, start int) ([]rune, int, error) {
+	var chars []rune
+	for i := start + 1; i < len(runes); i++ {
+		char := runes[i]
+		switch {
+		case char == ']':
+			if len(chars) == 0 {
+				return nil, 0, patternError(pattern, start, "empty class")
+			}
+			return uniqueChars(chars), i, nil
+		case char == '\\':
+			if i+1 == len(runes) {
+				return nil, 0, patternError(pattern, i, "trailing escape")
+			}
+			i++
+			chars = append(chars, runes[i])
+		case i+2 < len(runes) && runes[i+1] == '-' && runes[i+2] != ']':
+			last := runes[i+2]
+			if last < char {
+				return nil, 0, patternError(pattern, i, "invalid range "+string(runes[i:i+3]))
+			}
+			for c := char; c <= last; c++ {
+				chars = append(chars, c)
+			}
+			i += 2
+		default:
+			chars = append(chars, char)
+		}
+	}
+	return nil, 0, patternError(pattern, start, "unterminated class")
+}
+
+func patternError(pattern string, position int, reason string) error {
+	return fmt.Errorf("%w %q at position %d: %s", ErrInvalidPattern, pattern, position, reason)
+}
+
+func (pt *Pattern) String() string {
+	return pt.source
+}
+
+// Len returns the number of characters of a generated password.
+func (pt *Pattern) Len() int {
+	return len(pt.positions)
+}
+
+// Entropy returns the exact entropy in bits of a password from Generate, the
+// sum of log2 of the number of characters allowed at each position.
+func (pt *Pattern) Entropy() float64 {
+	entropy := 0.0
+	for _, chars := range pt.positions {
+		entropy += math.Log2(float64(len(chars)))
+	}
+	return entropy
+}
+
+func (pt *Pattern) Generate() (string, error) {
+	var plain strings.Builder
+	for _, chars := range pt.positions {
+		char, err := getRandomChar(chars)
+		if err != nil {
+			return "", err
+		}
+		plain.WriteRune(char)
+	}
+	return plain.String(), nil
+}
+
+// Check reports why pattern can never generate a password accepted by
+// pasRule: characters the rules exclude at a position without alternatives,
+// a length outside the length rule, or too few positions able to hold a
+// character class the character rule requires. Check passing does not
+// guarantee every generated password is accepted.
+func (pt *Pattern) Check(pasRule *rule.PaswotRule) error {
+	_, err := pt.restrict(pasRule)
+	return err
+}
+
+// restrict returns the pattern without the characters excluded by pasRule,
+// after checking it against pasRule as described for Check.
+func (pt *Pattern) restrict(pasRule *rule.PaswotRule) (*Pattern, error) {
+	excluded := pasRule.GenerationHint().Excluded
+	restricted := &Pattern{source: pt.source, positions: make([][]rune, len(pt.positions))}
+	for i, chars := range pt.positions {
+		restricted.positions[i] = withoutChars(rule.Charset(chars), excluded)
+		if len(restricted.positions[i]) == 0 {
+			return nil, fmt.Errorf("pattern %q position %d only allows excluded characters", pt.source, i)
+		}
+	}
+
+	if length := pasRule.Length; length != nil {
+		// Every position is one code point; count the shortest and longest
+		// possible password in the rule's unit.
+		var shortest, longest strings.Builder
+		for _, chars := range restricted.positions {
+			shortest.WriteRune(slices.MinFunc(chars, compareRuneLength(length.Unit)))
+			longest.WriteRune(slices.MaxFunc(chars, compareRuneLength(length.Unit)))
+		}
+		if length.Unit.Count(longest.String()) < length.Min || length.Unit.Count(shortest.String()) > length.Max {
+			return nil, fmt.Errorf("pattern %q cannot generate passwords between %d and %d %s long", pt.source, length.Min, length.Max, length.Unit)
+		}
+	}
+
+	if character := pasRule.Character; character != nil {
+		possible := rule.CharacterCounts{Classes: make([]int, len(character.Classes))}
+		for _, chars := range restricted.positions {
+			counts := character.Counts(string(chars))
+			possible.Uppercase += min(counts.Uppercase, 1)
+			possible.Lowercase += min(counts.Lowercase, 1)
+			possible.Number += min(counts.Number, 1)
+			possible.Symbol += min(counts.Symbol, 1)
+			for i, count := range counts.Classes {
+				possible.Classes[i] += min(count, 1)
+			}
+		}
+		type requirement struct {
+			name          string
+			possible, min int
+		}
+		requirements := []requirement{
+			{"uppercase", possible.Uppercase, character.MinUppercase},
+			{"lowercase", possible.Lowercase, character.MinLowercase},
+			{"number", possible.Number, character.MinNumber},
+			{"symbol", possible.Symbol, character.MinSymbol},
+		}
+		for i, class := range character.Classes {
+			requirements = append(requirements, requirement{class.Name, possible.Classes[i], class.Min})
+		}
+		for _, class := range requirements {
+			if class.possible < class.min {
+				return nil, fmt.Errorf("pattern %q has %d positions for %s characters, the rule requires %d", pt.source, class.possible, class.name, class.min)
+			}
+		}
+	}
+
+	return restricted, nil
+}
+
+func compareRuneLength(unit rule.LengthUnit) func(a, b rune) int {
+	return func(a, b rune) int {
+		return unit.Count(string(a)) - unit.Count(string(b))
+	}
+}
+
+// GenerateFromPattern sets Plain to a password generated by pattern that
+// satisfies pasRule, or the default rule when pasRule is nil. Characters
+// excluded by pasRule are never generated, and Entropy is the entropy of
+// the pattern without them. Candidates that pasRule rejects for any other
+// reason are drawn again, so Entropy is then an upper bound.
+func (p *Paswot) GenerateFromPattern(pattern *Pattern, pasRule *rule.PaswotRule) error {
+	if pasRule == nil {
+		pasRule = rule.DefaultRule()
+	}
+
+	_, err := pasRule.IsValid()
+	if err != nil {
+		return err
+	}
+
+	restricted, err := pattern.restrict(pasRule)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; attempt < maxGenerateAttempts; attempt++ {
+		plain, err := restricted.Generate()
+		if err != nil {
+			return err
+		}
+
+		candidate := &Paswot{Plain: plain}
+		if _, err := candidate.Validate(pasRule); err == nil {
+			p.Plain = plain
+			p.Entropy = restricted.Entropy()
+			return nil
+		}
+	}
+
+	return fmt.Errorf("could not generate a password from pattern %q satisfying all rules", pattern.source)
+}
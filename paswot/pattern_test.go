@@ -0,0 +1,133 @@
+package paswot
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/wissensalt/paswot/rule"
+)
+
+func TestCompilePattern(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		regexp  string
+		entropy float64
+	}{
+		{"Aaaa-9999-aaaa", `^[A-Z][a-z]{3}-[0-9]{4}-[a-z]{4}$`, 8*math.Log2(26) + 4*math.Log2(10)},
+		{"[A-Z]{4}-[0-9]{4}", `^[A-Z]{4}-[0-9]{4}$`, 4*math.Log2(26) + 4*math.Log2(10)},
+		{"[a-f0-9]{8}", `^[a-f0-9]{8}$`, 8 * 4},
+		{`\A\9\\[\]-]{2}`, `^A9\\[\]-]{2}$`, 2},
+		{"#*", `^[!-/:-@\[-` + "`" + `{-~][!-~]$`, math.Log2(float64(len(rule.Symbol))) + math.Log2(float64(len(rule.All)))},
+		{"ID-99", `^ID-[0-9]{2}$`, 2 * math.Log2(10)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pattern, func(t *testing.T) {
+			pattern, err := CompilePattern(tc.pattern)
+			if err != nil {
+				t.Fatalf("CompilePattern() error = %v", err)
+			}
+			if pattern.String() != tc.pattern {
+				t.Errorf("String() = %q, want %q", pattern.String(), tc.pattern)
+			}
+
+			plain, err := pattern.Generate()
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if !regexp.MustCompile(tc.regexp).MatchString(plain) {
+				t.Errorf("Generate() = %q, does not match %s", plain, tc.regexp)
+			}
+			if len([]rune(plain)) != pattern.Len() {
+				t.Errorf("Len() = %d, generated %q", pattern.Len(), plain)
+			}
+			if math.Abs(pattern.Entropy()-tc.entropy) > 1e-9 {
+				t.Errorf("Entropy() = %f, want %f", pattern.Entropy(), tc.entropy)
+			}
+		})
+	}
+}
+
+func TestCompilePattern_Invalid(t *testing.T) {
+	for _, pattern := range []string{"", `abc\`, "[A-Z", "[]", "[z-a]", "{4}", "A{0}", "A{x}", "A{4", "A{2}{3}", "a]", "A{2000}", "*{1000}*{1000}"} {
+		if _, err := CompilePattern(pattern); !errors.Is(err, ErrInvalidPattern) {
+			t.Errorf("CompilePattern(%q) error = %v, want ErrInvalidPattern", pattern, err)
+		}
+	}
+}
+
+func TestPattern_Check(t *testing.T) {
+	pasRule := rule.NewPaswotRuleBuilder().
+		WithLength(rule.NewLengthRule(8, 16)).
+		WithCharacter(rule.NewCharacterRule(1, 1, 2, 0)).
+		Build()
+
+	testCases := []struct {
+		pattern string
+		errText string
+	}{
+		{"Aaaa-9999", ""},
+		{"Aaaa", "between 8 and 16"},
+		{"Aaaa-9999-aaaa-9999", "between 8 and 16"},
+		{"aaaa-9999", "uppercase"},
+		{"Aaaa-a9aa", "number"},
+		{"*{8}", ""},
+	}
+
+	hex := rule.NewPaswotRuleBuilder().
+		WithCharacter(rule.NewCharacterRuleBuilder().
+			WithClass("hex", "abcdef", 2).
+			Build()).
+		Build()
+	if err := MustCompilePattern("[a-f]9999999").Check(hex); err == nil || !strings.Contains(err.Error(), "hex") {
+		t.Errorf("Check() error = %v, want error about the hex class", err)
+	}
+	if err := MustCompilePattern("[a-f]{2}999999").Check(hex); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pattern, func(t *testing.T) {
+			err := MustCompilePattern(tc.pattern).Check(pasRule)
+			if tc.errText == "" {
+				if err != nil {
+					t.Errorf("Check() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.errText) {
+				t.Errorf("Check() error = %v, want error containing %q", err, tc.errText)
+			}
+		})
+	}
+}
+
+func TestPaswot_GenerateFromPattern(t *testing.T) {
+	pasRule := rule.NewPaswotRuleBuilder().
+		WithLength(rule.NewLengthRule(8, 16)).
+		WithCharacter(rule.NewCharacterRule(2, 0, 1, 0)).
+		WithNoWhitespace(rule.NewNoWhitespaceRule()).
+		Build()
+
+	p := NewPaswot()
+	if err := p.GenerateFromPattern(MustCompilePattern("[A-Z]{4}-[0-9]{4}"), pasRule); err != nil {
+		t.Fatalf("GenerateFromPattern() error = %v", err)
+	}
+	if !regexp.MustCompile(`^[A-Z]{4}-[0-9]{4}$`).MatchString(p.Plain) {
+		t.Errorf("GenerateFromPattern() = %q", p.Plain)
+	}
+	want := 4*math.Log2(26) + 4*math.Log2(10)
+	if math.Abs(p.Entropy-want) > 1e-9 {
+		t.Errorf("Entropy = %f, want %f", p.Entropy, want)
+	}
+
+	if err := p.GenerateFromPattern(MustCompilePattern("[A-Z]{4} 9999"), pasRule); err == nil {
+		t.Error("GenerateFromPattern() with excluded whitespace should return an error")
+	}
+	if err := p.GenerateFromPattern(MustCompilePattern("aaaa-9999"), pasRule); err == nil {
+		t.Error("GenerateFromPattern() without uppercase positions should return an error")
+	}
+}
@@ -0,0 +1,94 @@
+package paswot
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const pbkdf2SHA256ID = "pbkdf2-sha256"
+
+// maxPBKDF2Iterations limits the iterations of stored hashes so that a
+// corrupted record cannot stall verification.
+const maxPBKDF2Iterations = 10_000_000
+
+type PBKDF2Algorithm struct {
+	Iterations int
+	KeyLen     uint32
+	SaltLen    uint32
+}
+
+func NewPBKDF2Algorithm(iterations int) *PBKDF2Algorithm {
+	return &PBKDF2Algorithm{Iterations: iterations, KeyLen: 32, SaltLen: 16}
+}
+
+// DefaultPBKDF2Algorithm uses the OWASP recommendation of 600,000 iterations
+// for PBKDF2-HMAC-SHA256.
+func DefaultPBKDF2Algorithm() *PBKDF2Algorithm {
+	return NewPBKDF2Algorithm(600000)
+}
+
+func (a *PBKDF2Algorithm) Name() string {
+	return pbkdf2SHA256ID
+}
+
+func (a *PBKDF2Algorithm) Hash(password []byte) ([]byte, error) {
+	salt, err := randomBytes(a.SaltLen)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &PHC{
+		ID:     pbkdf2SHA256ID,
+		Params: []PHCParam{{Key: "i", Value: strconv.Itoa(a.Iterations)}},
+		Salt:   salt,
+		Hash:   pbkdf2.Key(password, salt, a.Iterations, int(a.KeyLen), sha256.New),
+	}
+	return []byte(h.String()), nil
+}
+
+func (a *PBKDF2Algorithm) Compare(hashed, password []byte) error {
+	h, params, err := parsePBKDF2(hashed)
+	if err != nil {
+		return err
+	}
+
+	otherKey := pbkdf2.Key(password, h.Salt, params.Iterations, len(h.Hash), sha256.New)
+	if subtle.ConstantTimeCompare(h.Hash, otherKey) != 1 {
+		return ErrMismatchedHashAndPassword
+	}
+	return nil
+}
+
+func (a *PBKDF2Algorithm) NeedsRehash(hashed []byte) bool {
+	_, params, err := parsePBKDF2(hashed)
+	if err != nil {
+		return true
+	}
+	return params.Iterations < a.Iterations || params.KeyLen < a.KeyLen || params.SaltLen < a.SaltLen
+}
+
+func parsePBKDF2(hashed []byte) (*PHC, *PBKDF2Algorithm, error) {
+	h, err := parsePHCFor(hashed, pbkdf2SHA256ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	iterations, err := h.IntParam("i")
+	if err != nil {
+		return nil, nil, err
+	}
+	if iterations <= 0 || iterations > maxPBKDF2Iterations {
+		return nil, nil, fmt.Errorf("%w: pbkdf2 iterations out of range", ErrInvalidPHC)
+	}
+
+	params := &PBKDF2Algorithm{
+		Iterations: iterations,
+		KeyLen:     uint32(len(h.Hash)),
+		SaltLen:    uint32(len(h.Salt)),
+	}
+	return h, params, nil
+}
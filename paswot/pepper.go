@@ -0,0 +1,138 @@
+package paswot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MinPepperKeyLength is the minimum size of a pepper key, giving at least
+// 128 bits of secret.
+const MinPepperKeyLength = 16
+
+const pepperPrefix = "$pepper$k="
+
+var (
+	ErrNoActivePepper      = errors.New("pepper keyring has no active key")
+	ErrUnknownPepperKey    = errors.New("unknown pepper key")
+	ErrPepperKeyIDRequired = errors.New("hash does not carry a pepper key identifier")
+)
+
+// PepperKeyring holds the pepper keys of an application. Hashing always uses
+// the active key, whose identifier is embedded in the stored hash as
+//
+//	$pepper$k=<id>$<inner hash>
+//
+// so that hashes made with a retired key can still be verified until the key
+// is removed.
+type PepperKeyring struct {
+	mu     sync.RWMutex
+	active string
+	keys   map[string][]byte
+}
+
+func NewPepperKeyring() *PepperKeyring {
+	return &PepperKeyring{keys: map[string][]byte{}}
+}
+
+// Add registers a key. The first key added becomes the active one.
+func (k *PepperKeyring) Add(id string, key []byte) error {
+	if !isPHCValue(id) {
+		return fmt.Errorf("invalid pepper key identifier %q", id)
+	}
+	if len(key) < MinPepperKeyLength {
+		return fmt.Errorf("pepper key must be at least %d bytes", MinPepperKeyLength)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if _, exists := k.keys[id]; exists {
+		return fmt.Errorf("pepper key %q already exists", id)
+	}
+	k.keys[id] = append([]byte(nil), key...)
+	if k.active == "" {
+		k.active = id
+	}
+	return nil
+}
+
+// Activate makes id the key used by new hashes. The previously active key is
+// retired: it is kept for verification only.
+func (k *PepperKeyring) Activate(id string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if _, ok := k.keys[id]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownPepperKey, id)
+	}
+	k.active = id
+	return nil
+}
+
+// Remove drops a retired key once no stored hash depends on it anymore.
+func (k *PepperKeyring) Remove(id string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if id == k.active {
+		return fmt.Errorf("cannot remove active pepper key %q", id)
+	}
+	if _, ok := k.keys[id]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownPepperKey, id)
+	}
+	delete(k.keys, id)
+	return nil
+}
+
+func (k *PepperKeyring) ActiveID() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.active
+}
+
+// NeedsRotation reports whether hashed was not produced with the active key.
+func (k *PepperKeyring) NeedsRotation(hashed string) bool {
+	id, _, ok := splitPeppered(hashed)
+	return !ok || id != k.ActiveID()
+}
+
+func (k *PepperKeyring) hash(algorithm Algorithm, input []byte) ([]byte, error) {
+	k.mu.RLock()
+	id, key := k.active, k.keys[k.active]
+	k.mu.RUnlock()
+	if id == "" {
+		return nil, ErrNoActivePepper
+	}
+
+	inner, err := algorithm.Hash(hmacBase64(key, input))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(pepperPrefix+id), inner...), nil
+}
+
+func (k *PepperKeyring) compare(hashed string, input []byte) error {
+	id, inner, ok := splitPeppered(hashed)
+	if !ok {
+		return ErrPepperKeyIDRequired
+	}
+
+	k.mu.RLock()
+	key, ok := k.keys[id]
+	k.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownPepperKey, id)
+	}
+	return compare(inner, hmacBase64(key, input))
+}
+
+func splitPeppered(hashed string) (id, inner string, ok bool) {
+	rest, ok := strings.CutPrefix(hashed, pepperPrefix)
+	if !ok {
+		return "", "", false
+	}
+	i := strings.IndexByte(rest, '$')
+	if i <= 0 {
+		return "", "", false
+	}
+	return rest[:i], rest[i:], true
+}
@@ -0,0 +1,113 @@
+package paswot
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newTestKeyring(t *testing.T, ids ...string) *PepperKeyring {
+	t.Helper()
+	keyring := NewPepperKeyring()
+	for _, id := range ids {
+		if err := keyring.Add(id, []byte(strings.Repeat(id, MinPepperKeyLength))); err != nil {
+			t.Fatalf("Add(%q) error = %v", id, err)
+		}
+	}
+	return keyring
+}
+
+func TestPepperKeyring_Add(t *testing.T) {
+	keyring := newTestKeyring(t, "k1", "k2")
+	if keyring.ActiveID() != "k1" {
+		t.Errorf("ActiveID() = %q, want %q", keyring.ActiveID(), "k1")
+	}
+
+	if err := keyring.Add("k1", []byte(strings.Repeat("x", MinPepperKeyLength))); err == nil {
+		t.Error("Add() with duplicate identifier should return an error")
+	}
+	if err := keyring.Add("k$3", []byte(strings.Repeat("x", MinPepperKeyLength))); err == nil {
+		t.Error("Add() with invalid identifier should return an error")
+	}
+	if err := keyring.Add("k3", []byte("short")); err == nil {
+		t.Error("Add() with short key should return an error")
+	}
+}
+
+func TestPepperKeyring_Rotation(t *testing.T) {
+	keyring := newTestKeyring(t, "k1", "k2")
+	options := NewHashOptionsBuilder().WithCost(bcrypt.MinCost).Build()
+
+	p := NewPaswotWithSaltAndKeyring("salt", keyring, options)
+	p.Plain = "password"
+
+	oldHash, err := p.Hash()
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if !strings.HasPrefix(string(oldHash), "$pepper$k=k1$2a$04$") {
+		t.Errorf("Hash() = %q, want prefix %q", oldHash, "$pepper$k=k1$2a$04$")
+	}
+
+	if err := keyring.Activate("k2"); err != nil {
+		t.Fatalf("Activate() error = %v", err)
+	}
+	newHash, err := p.Hash()
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if !strings.HasPrefix(string(newHash), "$pepper$k=k2$") {
+		t.Errorf("Hash() after rotation = %q, want prefix %q", newHash, "$pepper$k=k2$")
+	}
+
+	// Retired keys still verify
+	if !p.Match(string(oldHash)) || !p.Match(string(newHash)) {
+		t.Error("Match() should verify hashes made with both retired and active keys")
+	}
+	if !keyring.NeedsRotation(string(oldHash)) || keyring.NeedsRotation(string(newHash)) {
+		t.Error("NeedsRotation() should only report hashes made with a retired key")
+	}
+
+	matched, upgraded, err := VerifyAndUpgrade(p, string(oldHash), NewHashPolicy(NewBcryptAlgorithm(bcrypt.MinCost)))
+	if err != nil || !matched || !strings.HasPrefix(string(upgraded), "$pepper$k=k2$") {
+		t.Errorf("VerifyAndUpgrade() = %v, %q, %v; want a hash with the active key", matched, upgraded, err)
+	}
+
+	// A different pepper never matches
+	otherKeyring := NewPepperKeyring()
+	if err := otherKeyring.Add("k1", []byte(strings.Repeat("x", MinPepperKeyLength))); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	other := NewPaswotWithSaltAndKeyring("salt", otherKeyring, options)
+	other.Plain = "password"
+	if other.Match(string(oldHash)) {
+		t.Error("Match() with a different key should be false, but got true")
+	}
+
+	if err := keyring.Remove("k2"); err == nil {
+		t.Error("Remove() of the active key should return an error")
+	}
+	if err := keyring.Remove("k1"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if p.Match(string(oldHash)) {
+		t.Error("Match() with a removed key should be false, but got true")
+	}
+}
+
+func TestPepperKeyring_NoActiveKey(t *testing.T) {
+	p := NewPaswotWithSaltAndKeyring("salt", NewPepperKeyring())
+	p.Plain = "password"
+	if _, err := p.Hash(); err != ErrNoActivePepper {
+		t.Errorf("Hash() error = %v, want %v", err, ErrNoActivePepper)
+	}
+
+	unpeppered, err := (&Paswot{Plain: "password", Algorithm: NewBcryptAlgorithm(bcrypt.MinCost)}).Hash()
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if p.Match(string(unpeppered)) {
+		t.Error("Match() against a hash without key identifier should be false, but got true")
+	}
+}
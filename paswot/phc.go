@@ -0,0 +1,176 @@
+package paswot
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidPHC = errors.New("invalid PHC string")
+
+type PHCParam struct {
+	Key   string
+	Value string
+}
+
+// PHC is a hash in the PHC string format:
+//
+//	$<id>[$v=<version>][$<param>=<value>(,<param>=<value>)*][$<salt>[$<hash>]]
+//
+// Salt and Hash are stored decoded; they are B64 encoded (standard base64
+// without padding) by String.
+type PHC struct {
+	ID      string
+	Version int
+	Params  []PHCParam
+	Salt    []byte
+	Hash    []byte
+}
+
+func (h *PHC) Param(key string) (string, bool) {
+	for _, param := range h.Params {
+		if param.Key == key {
+			return param.Value, true
+		}
+	}
+	return "", false
+}
+
+func (h *PHC) IntParam(key string) (int, error) {
+	value, ok := h.Param(key)
+	if !ok {
+		return 0, fmt.Errorf("%w: missing parameter %q", ErrInvalidPHC, key)
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: parameter %q is not an integer", ErrInvalidPHC, key)
+	}
+	return n, nil
+}
+
+func (h *PHC) String() string {
+	var b strings.Builder
+	b.WriteString("$" + h.ID)
+	if h.Version > 0 {
+		b.WriteString("$v=" + strconv.Itoa(h.Version))
+	}
+	if len(h.Params) > 0 {
+		params := make([]string, len(h.Params))
+		for i, param := range h.Params {
+			params[i] = param.Key + "=" + param.Value
+		}
+		b.WriteString("$" + strings.Join(params, ","))
+	}
+	if h.Salt != nil {
+		b.WriteString("$" + base64.RawStdEncoding.EncodeToString(h.Salt))
+		if h.Hash != nil {
+			b.WriteString("$" + base64.RawStdEncoding.EncodeToString(h.Hash))
+		}
+	}
+	return b.String()
+}
+
+func ParsePHC(s string) (*PHC, error) {
+	parts := strings.Split(s, "$")
+	if len(parts) < 2 || parts[0] != "" {
+		return nil, fmt.Errorf("%w: must start with '$'", ErrInvalidPHC)
+	}
+	parts = parts[1:]
+
+	h := &PHC{ID: parts[0]}
+	if !isPHCSymbol(h.ID) {
+		return nil, fmt.Errorf("%w: invalid identifier %q", ErrInvalidPHC, h.ID)
+	}
+	parts = parts[1:]
+
+	if len(parts) > 0 && strings.HasPrefix(parts[0], "v=") && !strings.Contains(parts[0], ",") {
+		version, err := strconv.Atoi(strings.TrimPrefix(parts[0], "v="))
+		if err != nil || version < 0 {
+			return nil, fmt.Errorf("%w: invalid version %q", ErrInvalidPHC, parts[0])
+		}
+		h.Version = version
+		parts = parts[1:]
+	}
+
+	if len(parts) > 0 && strings.Contains(parts[0], "=") {
+		for _, param := range strings.Split(parts[0], ",") {
+			key, value, ok := strings.Cut(param, "=")
+			if !ok || !isPHCSymbol(key) || !isPHCValue(value) {
+				return nil, fmt.Errorf("%w: invalid parameter %q", ErrInvalidPHC, param)
+			}
+			if _, exists := h.Param(key); exists {
+				return nil, fmt.Errorf("%w: duplicate parameter %q", ErrInvalidPHC, key)
+			}
+			h.Params = append(h.Params, PHCParam{Key: key, Value: value})
+		}
+		parts = parts[1:]
+	}
+
+	if len(parts) > 2 {
+		return nil, fmt.Errorf("%w: too many fields", ErrInvalidPHC)
+	}
+	if len(parts) > 0 {
+		salt, err := decodePHCBase64(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid salt encoding", ErrInvalidPHC)
+		}
+		h.Salt = salt
+	}
+	if len(parts) > 1 {
+		hash, err := decodePHCBase64(parts[1])
+		if err != nil || len(hash) == 0 {
+			return nil, fmt.Errorf("%w: invalid hash encoding", ErrInvalidPHC)
+		}
+		h.Hash = hash
+	}
+
+	return h, nil
+}
+
+// decodePHCBase64 also accepts padded input, which some implementations emit
+// despite the specification.
+func decodePHCBase64(s string) ([]byte, error) {
+	return base64.RawStdEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// parsePHCFor parses hashed and checks that it is a complete hash (salt and
+// digest present) produced by the algorithm identified by id.
+func parsePHCFor(hashed []byte, id string) (*PHC, error) {
+	h, err := ParsePHC(string(hashed))
+	if err != nil {
+		return nil, err
+	}
+	if h.ID != id {
+		return nil, fmt.Errorf("%w: expected identifier %q, got %q", ErrInvalidPHC, id, h.ID)
+	}
+	if h.Salt == nil || h.Hash == nil {
+		return nil, fmt.Errorf("%w: missing salt or hash", ErrInvalidPHC)
+	}
+	return h, nil
+}
+
+func isPHCSymbol(s string) bool {
+	if s == "" || len(s) > 32 {
+		return false
+	}
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func isPHCValue(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '/' && c != '+' && c != '.' && c != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+package paswot
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestParsePHC(t *testing.T) {
+	h, err := ParsePHC("$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$aGFzaA")
+	if err != nil {
+		t.Fatalf("ParsePHC() error = %v", err)
+	}
+
+	if h.ID != "argon2id" {
+		t.Errorf("ID = %q, want %q", h.ID, "argon2id")
+	}
+	if h.Version != 19 {
+		t.Errorf("Version = %d, want 19", h.Version)
+	}
+	if m, err := h.IntParam("m"); err != nil || m != 65536 {
+		t.Errorf("IntParam(\"m\") = %d, %v; want 65536", m, err)
+	}
+	if _, err := h.IntParam("x"); err == nil {
+		t.Error("IntParam(\"x\") should return an error for a missing parameter")
+	}
+	if !bytes.Equal(h.Salt, []byte("somesalt")) {
+		t.Errorf("Salt = %q, want %q", h.Salt, "somesalt")
+	}
+	if !bytes.Equal(h.Hash, []byte("hash")) {
+		t.Errorf("Hash = %q, want %q", h.Hash, "hash")
+	}
+}
+
+func TestPHC_String(t *testing.T) {
+	testCases := []string{
+		"$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$aGFzaA",
+		"$scrypt$ln=15,r=8,p=1$c29tZXNhbHQ$aGFzaA",
+		"$pbkdf2-sha256$i=1000$c29tZXNhbHQ$aGFzaA",
+		"$custom$c29tZXNhbHQ",
+		"$custom",
+	}
+
+	for _, s := range testCases {
+		h, err := ParsePHC(s)
+		if err != nil {
+			t.Fatalf("ParsePHC(%q) error = %v", s, err)
+		}
+		if got := h.String(); got != s {
+			t.Errorf("ParsePHC(%q).String() = %q", s, got)
+		}
+	}
+}
+
+func TestParsePHC_Invalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		phc  string
+	}{
+		{name: "Empty", phc: ""},
+		{name: "No leading dollar", phc: "argon2id$v=19"},
+		{name: "Uppercase identifier", phc: "$Argon2id$v=19"},
+		{name: "Invalid version", phc: "$argon2id$v=x$m=1$c2FsdA$aGFzaA"},
+		{name: "Invalid parameter", phc: "$argon2id$v=19$m=1,t$c2FsdA$aGFzaA"},
+		{name: "Duplicate parameter", phc: "$argon2id$v=19$m=1,m=2$c2FsdA$aGFzaA"},
+		{name: "Invalid salt", phc: "$argon2id$v=19$m=1$c2F*sdA$aGFzaA"},
+		{name: "Empty hash", phc: "$argon2id$v=19$m=1$c2FsdA$"},
+		{name: "Too many fields", phc: "$argon2id$v=19$m=1$c2FsdA$aGFzaA$aGFzaA"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ParsePHC(tc.phc); !errors.Is(err, ErrInvalidPHC) {
+				t.Errorf("ParsePHC(%q) error = %v, want %v", tc.phc, err, ErrInvalidPHC)
+			}
+		})
+	}
+}
+
+// The vectors below were produced by Python's hashlib, so they also check
+// interoperability with PHC strings written by other implementations.
+func TestPaswot_MatchExternalPHC(t *testing.T) {
+	testCases := []struct {
+		name   string
+		hashed string
+	}{
+		{name: "Scrypt", hashed: "$scrypt$ln=4,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$5f/Vi+XRWGUNGScbsma6KJ4zLFIke/NJsrvr7lQLAyA"},
+		{name: "PBKDF2", hashed: "$pbkdf2-sha256$i=1000$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA"},
+		{name: "Padded", hashed: "$pbkdf2-sha256$i=1000$c2FsdHNhbHRzYWx0c2FsdA==$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA="},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &Paswot{Plain: "password"}
+			if !p.Match(tc.hashed) {
+				t.Error("Match() with correct password should be true, but got false")
+			}
+
+			p.Plain = "wrongpassword"
+			if p.Match(tc.hashed) {
+				t.Error("Match() with incorrect password should be false, but got true")
+			}
+		})
+	}
+}
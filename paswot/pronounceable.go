@@ -0,0 +1,183 @@
+package paswot
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/wissensalt/paswot/rule"
+)
+
+// Syllables are a consonant and a vowel, optionally followed by a closing
+// consonant. Letters that are easily confused over the phone (c, q, w, x, y)
+// are left out. Every syllable starts with a single consonant and has a single
+// vowel, so a generated string splits into syllables in only one way and each
+// syllable adds exactly log2 of the number of syllables to the entropy.
+const (
+	pronounceableOnsets  = "bdfghjklmnprstvz"
+	pronounceableVowels  = "aeiou"
+	pronounceableCodas   = "klmnrst"
+	pronounceableSymbols = "!#$%*+-=?@"
+)
+
+// defaultPronounceableLength is the length generated when pasRule has no
+// minimum length.
+const defaultPronounceableLength = 10
+
+// GeneratePronounceable sets Plain to a pronounceable password, such as
+// "Mokarbi7tuz!", that satisfies pasRule. The letters are lowercase syllables;
+// the character rule's uppercase minimum is met by capitalizing the first
+// letter of each syllable and then further letters from the left, and its
+// digit and symbol minimums by inserting digits and symbols between
+// syllables. Syllables end without a consonant once another closing consonant
+// could exceed the maximum length. Symbols are limited to a set that is easy
+// to dictate unless the character rule allows none of them. Entropy ignores
+// the positions of the digits and symbols, and is a lower bound when the
+// maximum length limits the closing consonants.
+func (p *Paswot) GeneratePronounceable(pasRule *rule.PaswotRule) error {
+	if pasRule == nil {
+		pasRule = rule.DefaultRule()
+	}
+
+	_, err := pasRule.IsValid()
+	if err != nil {
+		return err
+	}
+
+	hint := pasRule.GenerationHint()
+	maxLength := 0
+	if pasRule.Length != nil {
+		maxLength = pasRule.Length.Max
+	}
+	for attempt := 0; attempt < maxGenerateAttempts; attempt++ {
+		plain, entropy, err := generatePronounceable(pasRule.Character, hint, maxLength)
+		if err != nil {
+			return err
+		}
+
+		candidate := &Paswot{Plain: plain}
+		if _, err := candidate.Validate(pasRule); err == nil {
+			p.Plain = plain
+			p.Entropy = entropy
+			return nil
+		}
+	}
+
+	return errors.New("could not generate a pronounceable password satisfying all rules")
+}
+
+// generatePronounceable draws a candidate of at most maxLength characters, or
+// of any length when maxLength is 0.
+func generatePronounceable(character *rule.CharacterRule, hint rule.GenerationHint, maxLength int) (string, float64, error) {
+	var uppercase, lowercase, digitCount, symbolCount int
+	if character != nil {
+		uppercase, lowercase = character.MinUppercase, character.MinLowercase
+		digitCount, symbolCount = character.MinNumber, character.MinSymbol
+	}
+
+	onsets := withoutChars(pronounceableOnsets, hint.Excluded)
+	vowels := withoutChars(pronounceableVowels, hint.Excluded)
+	codas := withoutChars(pronounceableCodas, hint.Excluded)
+	if len(onsets) == 0 || len(vowels) == 0 {
+		return "", 0, errors.New("no letters left to build syllables")
+	}
+
+	// A syllable has two or three letters, so half the letter count
+	// guarantees the minimum length. Closing consonants are only drawn while
+	// the remaining syllables still fit in the maximum length.
+	length := hint.MinLength
+	if length == 0 {
+		length = defaultPronounceableLength
+	}
+	letters := max(length-digitCount-symbolCount, uppercase+lowercase, 2)
+	syllables := make([][]rune, (letters+1)/2)
+	maxLetters := math.MaxInt
+	if maxLength > 0 {
+		maxLetters = maxLength - digitCount - symbolCount
+	}
+	if maxLetters < 2*len(syllables) {
+		return "", 0, errors.New("maximum length too short for a pronounceable password")
+	}
+
+	// One choice among all open and closed syllables, or among the open
+	// syllables once no closing consonant fits
+	openChoices := len(onsets) * len(vowels)
+	choices := openChoices * (1 + len(codas))
+	closable := min(len(syllables), maxLetters-2*len(syllables))
+	for i := range syllables {
+		available := choices
+		if closable == 0 {
+			available = openChoices
+		}
+		n, err := randomInt(available)
+		if err != nil {
+			return "", 0, err
+		}
+		syllables[i] = []rune{onsets[n%len(onsets)], vowels[n/len(onsets)%len(vowels)]}
+		if coda := n / openChoices; coda > 0 {
+			syllables[i] = append(syllables[i], codas[coda-1])
+			closable--
+		}
+	}
+	// The most likely passwords close the first syllables, which leaves only
+	// open syllables for the rest
+	closed := min(len(syllables), maxLetters-2*len(syllables))
+	entropy := float64(closed)*math.Log2(float64(choices)) + float64(len(syllables)-closed)*math.Log2(float64(openChoices))
+
+	// Capitalize the start of the syllables, then further letters
+	for _, onsetsOnly := range []bool{true, false} {
+		for _, syllable := range syllables {
+			for j := range syllable {
+				if uppercase == 0 || onsetsOnly && j > 0 {
+					break
+				}
+				if !unicode.IsUpper(syllable[j]) {
+					syllable[j] = unicode.ToUpper(syllable[j])
+					uppercase--
+				}
+			}
+		}
+	}
+
+	// Digits and symbols go after random syllables. Without an easily
+	// dictated symbol left, any allowed symbol is used.
+	symbols := withoutChars(pronounceableSymbols, hint.Excluded)
+	if len(symbols) == 0 && character != nil {
+		symbols = withoutChars(character.SymbolSet(), hint.Excluded)
+	}
+	extras := make([]string, len(syllables))
+	for _, extra := range []struct {
+		count   int
+		charset []rune
+	}{
+		{digitCount, withoutChars(rule.Number, hint.Excluded)},
+		{symbolCount, symbols},
+	} {
+		if extra.count == 0 {
+			continue
+		}
+		if len(extra.charset) == 0 {
+			return "", 0, errors.New("no characters left to add to the pronounceable password")
+		}
+		for i := 0; i < extra.count; i++ {
+			char, err := getRandomChar(extra.charset)
+			if err != nil {
+				return "", 0, err
+			}
+			n, err := randomInt(len(syllables))
+			if err != nil {
+				return "", 0, err
+			}
+			extras[n] += string(char)
+		}
+		entropy += rule.CharsetEntropy(rule.Charset(extra.charset), extra.count)
+	}
+
+	var plain strings.Builder
+	for i, syllable := range syllables {
+		plain.WriteString(string(syllable))
+		plain.WriteString(extras[i])
+	}
+	return plain.String(), entropy, nil
+}
@@ -0,0 +1,100 @@
+package paswot
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/wissensalt/paswot/rule"
+)
+
+var syllablePattern = regexp.MustCompile(`^(?:[bdfghjklmnprstvzBDFGHJKLMNPRSTVZ][aeiou][klmnrst]?[0-9!#$%*+\-=?@]*)+$`)
+
+func TestPaswot_GeneratePronounceable(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		p := NewPaswot()
+		if err := p.GeneratePronounceable(nil); err != nil {
+			t.Fatalf("GeneratePronounceable() error = %v", err)
+		}
+		if !syllablePattern.MatchString(p.Plain) {
+			t.Errorf("GeneratePronounceable() = %q, not made of syllables", p.Plain)
+		}
+		if _, err := p.Validate(rule.DefaultRule()); err != nil {
+			t.Errorf("GeneratePronounceable() = %q, which fails the default rule: %v", p.Plain, err)
+		}
+
+		// 8 characters with a digit and a symbol leave 6 letters, 3 syllables
+		want := 3*math.Log2(16*5*8) + math.Log2(10) + math.Log2(10)
+		if math.Abs(p.Entropy-want) > 1e-9 {
+			t.Fatalf("Entropy = %f, want %f", p.Entropy, want)
+		}
+	}
+}
+
+func TestPaswot_GeneratePronounceableWithRule(t *testing.T) {
+	pasRule := rule.NewPaswotRuleBuilder().
+		WithLength(rule.NewLengthRule(12, 20)).
+		WithCharacter(rule.NewCharacterRule(2, 1, 2, 0)).
+		WithNoWhitespace(rule.NewNoWhitespaceRule()).
+		Build()
+
+	p := NewPaswot()
+	if err := p.GeneratePronounceable(pasRule); err != nil {
+		t.Fatalf("GeneratePronounceable() error = %v", err)
+	}
+	if _, err := p.Validate(pasRule); err != nil {
+		t.Errorf("GeneratePronounceable() = %q, which fails validation: %v", p.Plain, err)
+	}
+	if regexp.MustCompile(`[!#$%*+\-=?@]`).MatchString(p.Plain) {
+		t.Errorf("GeneratePronounceable() = %q, want no symbols", p.Plain)
+	}
+
+	invalid := rule.NewPaswotRuleBuilder().WithLength(rule.NewLengthRule(10, 5)).Build()
+	if err := p.GeneratePronounceable(invalid); err == nil {
+		t.Error("GeneratePronounceable() with an invalid rule should return an error")
+	}
+}
+
+func TestPaswot_GeneratePronounceableMaxLength(t *testing.T) {
+	pasRule := rule.NewPaswotRuleBuilder().
+		WithLength(rule.NewLengthRule(14, 16)).
+		WithCharacter(rule.NewCharacterRule(1, 1, 1, 1)).
+		Build()
+
+	for i := 0; i < 50; i++ {
+		p := NewPaswot()
+		if err := p.GeneratePronounceable(pasRule); err != nil {
+			t.Fatalf("GeneratePronounceable() error = %v", err)
+		}
+		if _, err := p.Validate(pasRule); err != nil {
+			t.Errorf("GeneratePronounceable() = %q, which fails validation: %v", p.Plain, err)
+		}
+
+		// 12 letters in 6 syllables, at most 2 of them closed to stay within 14
+		want := 2*math.Log2(16*5*8) + 4*math.Log2(16*5) + math.Log2(10) + math.Log2(10)
+		if math.Abs(p.Entropy-want) > 1e-9 {
+			t.Fatalf("Entropy = %f, want %f", p.Entropy, want)
+		}
+	}
+}
+
+func TestPaswot_GeneratePronounceableUppercase(t *testing.T) {
+	pasRule := rule.NewPaswotRuleBuilder().
+		WithLength(rule.NewLengthRule(8, 16)).
+		WithCharacter(rule.NewCharacterRule(5, 1, 1, 1)).
+		Build()
+
+	for i := 0; i < 50; i++ {
+		p := NewPaswot()
+		if err := p.GeneratePronounceable(pasRule); err != nil {
+			t.Fatalf("GeneratePronounceable() error = %v", err)
+		}
+		if _, err := p.Validate(pasRule); err != nil {
+			t.Errorf("GeneratePronounceable() = %q, which fails validation: %v", p.Plain, err)
+		}
+		if !syllablePattern.MatchString(strings.ToLower(p.Plain)) {
+			t.Errorf("GeneratePronounceable() = %q, not made of syllables", p.Plain)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package paswot
+
+// HashPolicy describes the algorithm and parameters that stored hashes are
+// expected to meet. A nil Algorithm means DefaultAlgorithm.
+type HashPolicy struct {
+	Algorithm Algorithm
+}
+
+func NewHashPolicy(algorithm Algorithm) HashPolicy {
+	return HashPolicy{Algorithm: algorithm}
+}
+
+func (p HashPolicy) algorithm() Algorithm {
+	if p.Algorithm == nil {
+		return DefaultAlgorithm()
+	}
+	return p.Algorithm
+}
+
+// NeedsRehash reports whether hashed was produced by a different algorithm
+// than the policy's, or by the same algorithm with weaker parameters.
+// Hashes that cannot be identified or parsed always need a rehash. For
+// peppered hashes the inner hash is inspected; see also
+// PepperKeyring.NeedsRotation.
+func NeedsRehash(hashed string, policy HashPolicy) bool {
+	if _, inner, ok := splitPeppered(hashed); ok {
+		hashed = inner
+	}
+
+	algorithm, err := algorithmFor(hashed)
+	if err != nil {
+		return true
+	}
+
+	target := policy.algorithm()
+	if algorithm.Name() != target.Name() {
+		return true
+	}
+	return target.NeedsRehash([]byte(hashed))
+}
+
+type Upgrader interface {
+	Matcher
+	HashWith(algorithm Algorithm) ([]byte, error)
+}
+
+// VerifyAndUpgrade matches the credential against hashed and, when it matches
+// but the hash does not meet the policy or was peppered with a retired key,
+// returns a fresh hash to store in its place. upgraded is nil when the
+// password does not match or no upgrade is needed.
+func VerifyAndUpgrade(credential Upgrader, hashed string, policy HashPolicy) (matched bool, upgraded []byte, err error) {
+	if !credential.Match(hashed) {
+		return false, nil, nil
+	}
+	if !NeedsRehash(hashed, policy) && !needsRotation(credential, hashed) {
+		return true, nil, nil
+	}
+
+	upgraded, err = credential.HashWith(policy.algorithm())
+	if err != nil {
+		return true, nil, err
+	}
+	return true, upgraded, nil
+}
+
+func needsRotation(credential Upgrader, hashed string) bool {
+	peppered, ok := credential.(*WithSaltAndPepper)
+	return ok && peppered.Keyring != nil && peppered.Keyring.NeedsRotation(hashed)
+}
@@ -0,0 +1,106 @@
+package paswot
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestNeedsRehash(t *testing.T) {
+	bcryptHash, err := NewBcryptAlgorithm(bcrypt.MinCost).Hash([]byte("password"))
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	argon2idHash, err := NewArgon2idAlgorithm(1, 1024, 1).Hash([]byte("password"))
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	scryptHash, err := NewScryptAlgorithm(4, 8, 1).Hash([]byte("password"))
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	pbkdf2Hash, err := NewPBKDF2Algorithm(1000).Hash([]byte("password"))
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	testCases := []struct {
+		name   string
+		hashed []byte
+		policy HashPolicy
+		want   bool
+	}{
+		{name: "Bcrypt same cost", hashed: bcryptHash, policy: NewHashPolicy(NewBcryptAlgorithm(bcrypt.MinCost)), want: false},
+		{name: "Bcrypt lower cost", hashed: bcryptHash, policy: NewHashPolicy(NewBcryptAlgorithm(bcrypt.MinCost + 1)), want: true},
+		{name: "Bcrypt default policy", hashed: bcryptHash, policy: HashPolicy{}, want: true},
+		{name: "Bcrypt to argon2id", hashed: bcryptHash, policy: NewHashPolicy(NewArgon2idAlgorithm(1, 1024, 1)), want: true},
+		{name: "Argon2id same parameters", hashed: argon2idHash, policy: NewHashPolicy(NewArgon2idAlgorithm(1, 1024, 1)), want: false},
+		{name: "Argon2id weaker policy", hashed: argon2idHash, policy: NewHashPolicy(NewArgon2idAlgorithm(1, 512, 1)), want: false},
+		{name: "Argon2id lower memory", hashed: argon2idHash, policy: NewHashPolicy(NewArgon2idAlgorithm(1, 2048, 1)), want: true},
+		{name: "Argon2id fewer iterations", hashed: argon2idHash, policy: NewHashPolicy(NewArgon2idAlgorithm(2, 1024, 1)), want: true},
+		{name: "Scrypt same parameters", hashed: scryptHash, policy: NewHashPolicy(NewScryptAlgorithm(4, 8, 1)), want: false},
+		{name: "Scrypt lower N", hashed: scryptHash, policy: NewHashPolicy(NewScryptAlgorithm(5, 8, 1)), want: true},
+		{name: "PBKDF2 same iterations", hashed: pbkdf2Hash, policy: NewHashPolicy(NewPBKDF2Algorithm(1000)), want: false},
+		{name: "PBKDF2 fewer iterations", hashed: pbkdf2Hash, policy: NewHashPolicy(NewPBKDF2Algorithm(2000)), want: true},
+		{name: "Unknown algorithm", hashed: []byte("$md5$abc"), policy: HashPolicy{}, want: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := NeedsRehash(string(tc.hashed), tc.policy); got != tc.want {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestVerifyAndUpgrade(t *testing.T) {
+	p := NewPaswotWithSaltAndPepper("salt", "pepper")
+	p.Plain = "password"
+	p.Algorithm = NewBcryptAlgorithm(bcrypt.MinCost)
+
+	stale, err := p.Hash()
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	policy := NewHashPolicy(NewArgon2idAlgorithm(1, 1024, 1))
+
+	t.Run("StaleHash", func(t *testing.T) {
+		matched, upgraded, err := VerifyAndUpgrade(p, string(stale), policy)
+		if err != nil {
+			t.Fatalf("VerifyAndUpgrade() error = %v", err)
+		}
+		if !matched {
+			t.Fatal("VerifyAndUpgrade() should match the correct password")
+		}
+		if upgraded == nil {
+			t.Fatal("VerifyAndUpgrade() should return an upgraded hash")
+		}
+		if NeedsRehash(string(upgraded), policy) {
+			t.Errorf("Upgraded hash %q does not meet the policy", upgraded)
+		}
+		if !p.Match(string(upgraded)) {
+			t.Error("Upgraded hash should match the password")
+		}
+	})
+
+	t.Run("CurrentHash", func(t *testing.T) {
+		current, err := p.HashWith(policy.Algorithm)
+		if err != nil {
+			t.Fatalf("HashWith() error = %v", err)
+		}
+		matched, upgraded, err := VerifyAndUpgrade(p, string(current), policy)
+		if err != nil || !matched || upgraded != nil {
+			t.Errorf("VerifyAndUpgrade() = %v, %q, %v; want true, nil, nil", matched, upgraded, err)
+		}
+	})
+
+	t.Run("WrongPassword", func(t *testing.T) {
+		wrong := NewPaswotWithSaltAndPepper("salt", "pepper")
+		wrong.Plain = "wrongpassword"
+		matched, upgraded, err := VerifyAndUpgrade(wrong, string(stale), policy)
+		if err != nil || matched || upgraded != nil {
+			t.Errorf("VerifyAndUpgrade() = %v, %q, %v; want false, nil, nil", matched, upgraded, err)
+		}
+	})
+}
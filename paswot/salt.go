@@ -0,0 +1,75 @@
+package paswot
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// MinSaltLength is the minimum number of random bytes in a generated salt.
+	MinSaltLength = 16
+	// MinSaltEntropyBits is the minimum estimated entropy ValidateSalt accepts.
+	MinSaltEntropyBits = 64
+)
+
+var (
+	ErrSaltTooShort   = errors.New("salt is too short")
+	ErrSaltLowEntropy = errors.New("salt entropy is too low")
+)
+
+// GenerateSalt returns length random bytes from crypto/rand, encoded with
+// unpadded URL-safe base64 so the salt can be stored as text.
+func GenerateSalt(length int) (string, error) {
+	if length < MinSaltLength {
+		return "", fmt.Errorf("%w: %d bytes requested, minimum is %d", ErrSaltTooShort, length, MinSaltLength)
+	}
+	b, err := randomBytes(uint32(length))
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func NewPaswotWithRandomSalt(length int, options ...*HashOptions) (*WithSalt, error) {
+	salt, err := GenerateSalt(length)
+	if err != nil {
+		return nil, err
+	}
+	return NewPaswotWithSalt(salt, options...), nil
+}
+
+// ValidateSalt rejects salts that are too short or too repetitive to be
+// unique per user, such as constants like "mySalt". The entropy is estimated
+// from the character frequencies of the salt.
+func ValidateSalt(salt string) error {
+	minLength := base64.RawURLEncoding.EncodedLen(MinSaltLength)
+	if len(salt) < minLength {
+		return fmt.Errorf("%w: %d characters, minimum is %d", ErrSaltTooShort, len(salt), minLength)
+	}
+
+	if bits := shannonBits(salt); bits < MinSaltEntropyBits {
+		return fmt.Errorf("%w: estimated %.0f bits, minimum is %d", ErrSaltLowEntropy, bits, MinSaltEntropyBits)
+	}
+
+	return nil
+}
+
+func (p *WithSalt) ValidateSalt() error {
+	return ValidateSalt(p.Salt)
+}
+
+func shannonBits(s string) float64 {
+	counts := map[byte]int{}
+	for i := 0; i < len(s); i++ {
+		counts[s[i]]++
+	}
+
+	perChar := 0.0
+	for _, count := range counts {
+		freq := float64(count) / float64(len(s))
+		perChar -= freq * math.Log2(freq)
+	}
+	return perChar * float64(len(s))
+}
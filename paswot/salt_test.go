@@ -0,0 +1,68 @@
+package paswot
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateSalt(t *testing.T) {
+	salt, err := GenerateSalt(32)
+	if err != nil {
+		t.Fatalf("GenerateSalt() error = %v", err)
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(salt)
+	if err != nil {
+		t.Fatalf("GenerateSalt() returned invalid encoding: %v", err)
+	}
+	if len(decoded) != 32 {
+		t.Errorf("GenerateSalt(32) decoded length = %d, want 32", len(decoded))
+	}
+
+	other, err := GenerateSalt(32)
+	if err != nil {
+		t.Fatalf("GenerateSalt() error = %v", err)
+	}
+	if salt == other {
+		t.Error("GenerateSalt() returned the same salt twice")
+	}
+
+	if _, err := GenerateSalt(MinSaltLength - 1); !errors.Is(err, ErrSaltTooShort) {
+		t.Errorf("GenerateSalt(%d) error = %v, want %v", MinSaltLength-1, err, ErrSaltTooShort)
+	}
+}
+
+func TestNewPaswotWithRandomSalt(t *testing.T) {
+	p, err := NewPaswotWithRandomSalt(MinSaltLength)
+	if err != nil {
+		t.Fatalf("NewPaswotWithRandomSalt() error = %v", err)
+	}
+	if p.Paswot == nil {
+		t.Fatal("NewPaswotWithRandomSalt().Paswot should not be nil")
+	}
+	if err := p.ValidateSalt(); err != nil {
+		t.Errorf("ValidateSalt() on a generated salt error = %v", err)
+	}
+}
+
+func TestValidateSalt(t *testing.T) {
+	testCases := []struct {
+		name    string
+		salt    string
+		wantErr error
+	}{
+		{name: "Constant salt", salt: "mySalt", wantErr: ErrSaltTooShort},
+		{name: "Repetitive salt", salt: strings.Repeat("ab", 16), wantErr: ErrSaltLowEntropy},
+		{name: "Random salt", salt: "q3Xv9LrT0bZk2WmNc8YpHd", wantErr: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateSalt(tc.salt); !errors.Is(err, tc.wantErr) {
+				t.Errorf("ValidateSalt(%q) error = %v, want %v", tc.salt, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package paswot
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const scryptID = "scrypt"
+
+// Limits on the parameters of stored scrypt hashes, which scrypt needs
+// 128·r·2^ln bytes of memory and time proportional to r·p·2^ln to verify.
+const (
+	maxScryptLogN   = 20
+	maxScryptRP     = 1024
+	maxScryptMemory = 1 << 30 // bytes
+)
+
+// ScryptAlgorithm derives keys with scrypt using N = 2^LogN.
+type ScryptAlgorithm struct {
+	LogN    uint8
+	R       int
+	P       int
+	KeyLen  uint32
+	SaltLen uint32
+}
+
+func NewScryptAlgorithm(logN uint8, r, p int) *ScryptAlgorithm {
+	return &ScryptAlgorithm{LogN: logN, R: r, P: p, KeyLen: 32, SaltLen: 16}
+}
+
+func DefaultScryptAlgorithm() *ScryptAlgorithm {
+	return NewScryptAlgorithm(15, 8, 1)
+}
+
+func (a *ScryptAlgorithm) Name() string {
+	return scryptID
+}
+
+func (a *ScryptAlgorithm) Hash(password []byte) ([]byte, error) {
+	salt, err := randomBytes(a.SaltLen)
+	if err != nil {
+		return nil, err
+	}
+	key, err := scrypt.Key(password, salt, 1<<a.LogN, a.R, a.P, int(a.KeyLen))
+	if err != nil {
+		return nil, err
+	}
+
+	h := &PHC{
+		ID: scryptID,
+		Params: []PHCParam{
+			{Key: "ln", Value: strconv.Itoa(int(a.LogN))},
+			{Key: "r", Value: strconv.Itoa(a.R)},
+			{Key: "p", Value: strconv.Itoa(a.P)},
+		},
+		Salt: salt,
+		Hash: key,
+	}
+	return []byte(h.String()), nil
+}
+
+func (a *ScryptAlgorithm) Compare(hashed, password []byte) error {
+	h, params, err := parseScrypt(hashed)
+	if err != nil {
+		return err
+	}
+
+	otherKey, err := scrypt.Key(password, h.Salt, 1<<params.LogN, params.R, params.P, len(h.Hash))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(h.Hash, otherKey) != 1 {
+		return ErrMismatchedHashAndPassword
+	}
+	return nil
+}
+
+func (a *ScryptAlgorithm) NeedsRehash(hashed []byte) bool {
+	_, params, err := parseScrypt(hashed)
+	if err != nil {
+		return true
+	}
+	return params.LogN < a.LogN || params.R < a.R || params.P < a.P ||
+		params.KeyLen < a.KeyLen || params.SaltLen < a.SaltLen
+}
+
+func parseScrypt(hashed []byte) (*PHC, *ScryptAlgorithm, error) {
+	h, err := parsePHCFor(hashed, scryptID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logN, err := h.IntParam("ln")
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := h.IntParam("r")
+	if err != nil {
+		return nil, nil, err
+	}
+	p, err := h.IntParam("p")
+	if err != nil {
+		return nil, nil, err
+	}
+	if logN <= 0 || logN > maxScryptLogN || r <= 0 || p <= 0 || r > maxScryptRP/p || 128*r<<logN > maxScryptMemory {
+		return nil, nil, fmt.Errorf("%w: scrypt parameters out of range", ErrInvalidPHC)
+	}
+
+	params := &ScryptAlgorithm{
+		LogN:    uint8(logN),
+		R:       r,
+		P:       p,
+		KeyLen:  uint32(len(h.Hash)),
+		SaltLen: uint32(len(h.Salt)),
+	}
+	return h, params, nil
+}
@@ -0,0 +1,27 @@
+package paswot
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestScryptAlgorithm_Compare(t *testing.T) {
+	algorithm := NewScryptAlgorithm(4, 8, 1)
+	hashed, err := algorithm.Hash([]byte("password"))
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	if err := algorithm.Compare(hashed, []byte("password")); err != nil {
+		t.Errorf("Compare() with correct password error = %v", err)
+	}
+	if err := algorithm.Compare(hashed, []byte("wrongpassword")); !errors.Is(err, ErrMismatchedHashAndPassword) {
+		t.Errorf("Compare() with incorrect password error = %v, want %v", err, ErrMismatchedHashAndPassword)
+	}
+
+	malformed := strings.Replace(string(hashed), "ln=4", "ln=0", 1)
+	if err := algorithm.Compare([]byte(malformed), []byte("password")); err == nil {
+		t.Error("Compare() with malformed hash should return an error")
+	}
+}
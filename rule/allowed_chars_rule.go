@@ -0,0 +1,83 @@
+package rule
+
+import (
+	"strings"
+	"unicode"
+)
+
+// AllowedCharsRule rejects passwords containing a character that is neither
+// in Charset nor in one of the Unicode Categories, such as unicode.Letter or
+// unicode.Nd. With neither set only the characters of All are allowed. The
+// violation names the first offending character and its 1-based position,
+// counted in characters.
+type AllowedCharsRule struct {
+	Charset    Charset
+	Categories []*unicode.RangeTable
+}
+
+func NewAllowedCharsRule(charset Charset, categories ...*unicode.RangeTable) *AllowedCharsRule {
+	return &AllowedCharsRule{Charset: charset, Categories: categories}
+}
+
+type AllowedCharsRuleBuilder struct {
+	AllowedCharsRule *AllowedCharsRule
+}
+
+func NewAllowedCharsRuleBuilder() *AllowedCharsRuleBuilder {
+	return &AllowedCharsRuleBuilder{AllowedCharsRule: &AllowedCharsRule{}}
+}
+
+func (builder *AllowedCharsRuleBuilder) WithCharset(charset Charset) *AllowedCharsRuleBuilder {
+	builder.AllowedCharsRule.Charset = charset
+	return builder
+}
+
+func (builder *AllowedCharsRuleBuilder) WithCategories(categories ...*unicode.RangeTable) *AllowedCharsRuleBuilder {
+	builder.AllowedCharsRule.Categories = append(builder.AllowedCharsRule.Categories, categories...)
+	return builder
+}
+
+func (builder *AllowedCharsRuleBuilder) Build() *AllowedCharsRule {
+	return builder.AllowedCharsRule
+}
+
+func (r *AllowedCharsRule) Name() string {
+	return "allowed_chars"
+}
+
+// Allows reports whether char may be used.
+func (r *AllowedCharsRule) Allows(char rune) bool {
+	if r.Charset == "" && len(r.Categories) == 0 {
+		return strings.ContainsRune(string(All), char)
+	}
+	return strings.ContainsRune(string(r.Charset), char) || unicode.In(char, r.Categories...)
+}
+
+// GenerationHint excludes the characters of All that are not allowed.
+func (r *AllowedCharsRule) GenerationHint() GenerationHint {
+	var excluded strings.Builder
+	for _, char := range All {
+		if !r.Allows(char) {
+			excluded.WriteRune(char)
+		}
+	}
+	return GenerationHint{Excluded: excluded.String()}
+}
+
+func (r *AllowedCharsRule) Validate(password string) (bool, error) {
+	if violations := r.Violations(password); len(violations) > 0 {
+		return false, &violations[0]
+	}
+
+	return true, nil
+}
+
+func (r *AllowedCharsRule) Violations(password string) []Violation {
+	for position, char := range []rune(password) {
+		if !r.Allows(char) {
+			return []Violation{disallowedCharacter(r.Name(), char, position)}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package rule
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestAllowedCharsRuleBuilder(t *testing.T) {
+	rule := NewAllowedCharsRuleBuilder().
+		WithCharset("!?").
+		WithCategories(unicode.Letter).
+		WithCategories(unicode.Nd).
+		Build()
+
+	if rule.Charset != "!?" || len(rule.Categories) != 2 {
+		t.Errorf("AllowedCharsRuleBuilder.Build() = %+v", rule)
+	}
+}
+
+func TestAllowedCharsRule_Validate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     *AllowedCharsRule
+		password string
+		message  string
+	}{
+		{"Default allows All", NewAllowedCharsRule(""), "Abc123!@#", ""},
+		{"Default rejects space", NewAllowedCharsRule(""), "Abc 123", `password cannot contain " " (position 4)`},
+		{"Default rejects non-ASCII", NewAllowedCharsRule(""), "pässwört", `password cannot contain "ä" (position 2)`},
+		{"Charset", NewAllowedCharsRule("abc123"), "abc123cba", ""},
+		{"Charset rejects", NewAllowedCharsRule("abc123"), "abc1234", `password cannot contain "4" (position 7)`},
+		{"Categories", NewAllowedCharsRule("!", unicode.Letter, unicode.Nd), "Pässwört٣!", ""},
+		{"Categories reject emoji", NewAllowedCharsRule("!", unicode.Letter, unicode.Nd), "pass👍word", `password cannot contain "👍" (position 5)`},
+		{"NUL", NewAllowedCharsRule(All), "pass\x00word", `password cannot contain "U+0000" (position 5)`},
+		{"Control character", NewAllowedCharsRule("", unicode.L), "pass\u202eword", `password cannot contain "U+202E" (position 5)`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.rule.Validate(tc.password)
+			if tc.message == "" {
+				if err != nil {
+					t.Errorf("Validate(%q) error = %v", tc.password, err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrDisallowedCharacter) {
+				t.Fatalf("Validate(%q) error = %v, want ErrDisallowedCharacter", tc.password, err)
+			}
+			if err.Error() != tc.message {
+				t.Errorf("Validate(%q) error = %q, want %q", tc.password, err.Error(), tc.message)
+			}
+		})
+	}
+}
+
+func TestAllowedCharsRule_GenerationHint(t *testing.T) {
+	hint := NewAllowedCharsRule(AlphabetLowerCase + Number).GenerationHint()
+	if len(hint.Excluded) != len(AlphabetUpperCase)+len(Symbol) {
+		t.Errorf("GenerationHint().Excluded = %q", hint.Excluded)
+	}
+	if strings.ContainsAny(hint.Excluded, string(AlphabetLowerCase+Number)) {
+		t.Errorf("GenerationHint().Excluded = %q, contains allowed characters", hint.Excluded)
+	}
+}
+
+func TestPaswotRuleBuilder_WithAllowedChars(t *testing.T) {
+	paswotRule := NewPaswotRuleBuilder().
+		WithLength(NewLengthRule(4, 16)).
+		WithAllowedChars(NewAllowedCharsRule(All)).
+		Build()
+
+	report := paswotRule.Report("abc\x00def")
+	if codes := report.Codes(); len(codes) != 1 || codes[0] != CodeDisallowedCharacter {
+		t.Errorf("Report() codes = %v, want [%s]", codes, CodeDisallowedCharacter)
+	}
+}
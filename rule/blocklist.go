@@ -0,0 +1,70 @@
+package rule
+
+import (
+	"bytes"
+	_ "embed"
+	"sync"
+)
+
+//go:generate go run ./internal/bloomgen data/passwords.txt data/common_passwords.bloom
+
+// commonPasswordsBloom is a filter of data/passwords.txt, regenerated with
+// go generate.
+//
+//go:embed data/common_passwords.bloom
+var commonPasswordsBloom []byte
+
+// DefaultBlocklistSize is the number of passwords in DefaultBlocklist.
+//
+// The embedded filter is built from the 7,141-entry zxcvbn list, not from
+// the top 1M common passwords: no list of that size is bundled with this
+// package. It catches the most common passwords only. For 1M-entry
+// coverage, build a filter with BloomFilterBuilder or internal/bloomgen and
+// load it with LoadBloomFilter.
+const DefaultBlocklistSize = 7141
+
+// DefaultBlocklist returns the embedded filter of DefaultBlocklistSize common
+// passwords.
+var DefaultBlocklist = sync.OnceValue(func() *BloomFilter {
+	f, err := ReadBloomFilter(bytes.NewReader(commonPasswordsBloom))
+	if err != nil {
+		panic("rule: embedded blocklist is corrupt: " + err.Error())
+	}
+	return f
+})
+
+// BlocklistRule rejects passwords contained in a BloomFilter. As with any
+// Bloom filter, a small fraction of uncommon passwords is rejected too.
+type BlocklistRule struct {
+	Filter *BloomFilter
+}
+
+func NewBlocklistRule(filter *BloomFilter) *BlocklistRule {
+	return &BlocklistRule{Filter: filter}
+}
+
+// DefaultBlocklistRule uses DefaultBlocklist, see DefaultBlocklistSize for
+// its coverage.
+func DefaultBlocklistRule() *BlocklistRule {
+	return NewBlocklistRule(DefaultBlocklist())
+}
+
+func (r *BlocklistRule) Name() string {
+	return "blocklist"
+}
+
+func (r *BlocklistRule) Validate(password string) (bool, error) {
+	if violations := r.Violations(password); len(violations) > 0 {
+		return false, &violations[0]
+	}
+
+	return true, nil
+}
+
+func (r *BlocklistRule) Violations(password string) []Violation {
+	if r.Filter.Contains(password) {
+		return []Violation{newViolation(r.Name(), CodeBlocklisted, false, true, nil)}
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package rule
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDefaultBlocklist_ContainsEmbeddedList(t *testing.T) {
+	if n := len(strings.Fields(passwordsList)); n != DefaultBlocklistSize {
+		t.Errorf("embedded list has %d passwords, DefaultBlocklistSize is %d", n, DefaultBlocklistSize)
+	}
+
+	f := DefaultBlocklist()
+	for _, password := range strings.Fields(passwordsList) {
+		if !f.Contains(password) {
+			t.Fatalf("embedded blocklist is missing %q, regenerate it with go generate", password)
+		}
+	}
+}
+
+func TestBlocklistRule_Validate(t *testing.T) {
+	r := DefaultBlocklistRule()
+
+	for _, password := range []string{"password", "Password1", "P@ssw0rd", "QWERTY"} {
+		valid, err := r.Validate(password)
+		if valid || !errors.Is(err, ErrBlocklisted) {
+			t.Errorf("Validate(%q) = %v, %v, want false, ErrBlocklisted", password, valid, err)
+		}
+	}
+
+	if valid, err := r.Validate("xK9#mQ2$vL7!"); !valid || err != nil {
+		t.Errorf("Validate(%q) = %v, %v, want true, nil", "xK9#mQ2$vL7!", valid, err)
+	}
+}
+
+func TestPaswotRule_WithBlocklist(t *testing.T) {
+	filter := NewBloomFilterBuilder().Add("Summer2024!").Build()
+	paswotRule := NewPaswotRuleBuilder().
+		WithLength(NewLengthRule(8, 16)).
+		WithBlocklist(NewBlocklistRule(filter)).
+		Build()
+
+	if codes := paswotRule.Report("summer2024!").Codes(); len(codes) != 1 || codes[0] != CodeBlocklisted {
+		t.Errorf("Report codes = %v, want [%s]", codes, CodeBlocklisted)
+	}
+}
@@ -0,0 +1,226 @@
+package rule
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+var ErrInvalidBloomFilter = errors.New("invalid bloom filter")
+
+// bloomMagic starts every serialized BloomFilter.
+const bloomMagic = "PWBLOOM1"
+
+// maxBloomFilterSize bounds the bits ReadBloomFilter accepts, 1 GiB, enough
+// for over 500M passwords at a 0.1% false positive rate.
+const maxBloomFilterSize = 1 << 33
+
+// bloomReadChunk is the number of words ReadBloomFilter reads at once, so a
+// header claiming a large size only allocates as the data arrives.
+const bloomReadChunk = 1 << 16
+
+// BloomFilter is a compact probabilistic set of passwords. Contains never
+// misses a password that was added, but reports a password that was not added
+// with a small probability chosen when the filter is built. When Normalized is
+// set, entries and lookups go through NormalizePassword.
+type BloomFilter struct {
+	Normalized bool
+	bits       []uint64
+	size       uint64
+	hashes     uint32
+}
+
+// NewBloomFilter sizes a filter for items entries at the given false positive
+// rate.
+func NewBloomFilter(items int, falsePositiveRate float64) *BloomFilter {
+	items = max(items, 1)
+	size := uint64(math.Ceil(-float64(items) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	size = max(size, 64)
+	hashes := uint32(max(1, math.Round(float64(size)/float64(items)*math.Ln2)))
+	return &BloomFilter{bits: make([]uint64, (size+63)/64), size: size, hashes: hashes}
+}
+
+func (f *BloomFilter) Add(password string) {
+	h1, h2 := f.hash(password)
+	for i := uint32(0); i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % f.size
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+func (f *BloomFilter) Contains(password string) bool {
+	h1, h2 := f.hash(password)
+	for i := uint32(0); i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % f.size
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// hash derives the two hashes combined by double hashing into the filter's
+// bit positions.
+func (f *BloomFilter) hash(password string) (uint64, uint64) {
+	if f.Normalized {
+		password = NormalizePassword(password)
+	}
+	h := fnv.New128a()
+	h.Write([]byte(password))
+	sum := h.Sum(nil)
+	return binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:]) | 1
+}
+
+// WriteTo serializes the filter in the format read by ReadBloomFilter.
+func (f *BloomFilter) WriteTo(w io.Writer) (int64, error) {
+	out := bufio.NewWriter(w)
+	header := make([]byte, len(bloomMagic)+1+4+8)
+	copy(header, bloomMagic)
+	if f.Normalized {
+		header[len(bloomMagic)] = 1
+	}
+	binary.BigEndian.PutUint32(header[len(bloomMagic)+1:], f.hashes)
+	binary.BigEndian.PutUint64(header[len(bloomMagic)+5:], f.size)
+	if _, err := out.Write(header); err != nil {
+		return 0, err
+	}
+	if err := binary.Write(out, binary.BigEndian, f.bits); err != nil {
+		return 0, err
+	}
+	return int64(len(header) + 8*len(f.bits)), out.Flush()
+}
+
+func (f *BloomFilter) WriteFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteTo(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func ReadBloomFilter(r io.Reader) (*BloomFilter, error) {
+	header := make([]byte, len(bloomMagic)+1+4+8)
+	if _, err := io.ReadFull(r, header); err != nil || string(header[:len(bloomMagic)]) != bloomMagic {
+		return nil, ErrInvalidBloomFilter
+	}
+	f := &BloomFilter{
+		Normalized: header[len(bloomMagic)] == 1,
+		hashes:     binary.BigEndian.Uint32(header[len(bloomMagic)+1:]),
+		size:       binary.BigEndian.Uint64(header[len(bloomMagic)+5:]),
+	}
+	if f.hashes == 0 || f.size == 0 || f.size > maxBloomFilterSize {
+		return nil, ErrInvalidBloomFilter
+	}
+	for remaining := int((f.size + 63) / 64); remaining > 0; {
+		chunk := make([]uint64, min(remaining, bloomReadChunk))
+		if err := binary.Read(r, binary.BigEndian, chunk); err != nil {
+			return nil, ErrInvalidBloomFilter
+		}
+		f.bits = append(f.bits, chunk...)
+		remaining -= len(chunk)
+	}
+	return f, nil
+}
+
+func LoadBloomFilter(path string) (*BloomFilter, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadBloomFilter(bufio.NewReader(file))
+}
+
+// normalizedL33t maps common substitutions back to a letter. Ambiguous ones
+// such as "1" (i or l) map to a single letter; as both the filter entries and
+// the lookups are normalized the same way this only matters for collisions.
+var normalizedL33t = strings.NewReplacer(
+	"4", "a", "@", "a",
+	"8", "b",
+	"3", "e",
+	"6", "g", "9", "g",
+	"1", "i", "!", "i", "|", "i",
+	"0", "o",
+	"$", "s", "5", "s",
+	"7", "t", "+", "t",
+	"2", "z",
+)
+
+// NormalizePassword lowercases password and undoes common l33t
+// substitutions, so "P@ssw0rd" and "password" normalize to the same value.
+func NormalizePassword(password string) string {
+	return normalizedL33t.Replace(strings.ToLower(password))
+}
+
+type BloomFilterBuilder struct {
+	FalsePositiveRate float64
+	Normalize         bool
+	passwords         map[string]struct{}
+}
+
+func NewBloomFilterBuilder() *BloomFilterBuilder {
+	return &BloomFilterBuilder{FalsePositiveRate: 0.001, Normalize: true, passwords: map[string]struct{}{}}
+}
+
+func (builder *BloomFilterBuilder) WithFalsePositiveRate(falsePositiveRate float64) *BloomFilterBuilder {
+	builder.FalsePositiveRate = falsePositiveRate
+	return builder
+}
+
+func (builder *BloomFilterBuilder) WithNormalization(normalize bool) *BloomFilterBuilder {
+	builder.Normalize = normalize
+	return builder
+}
+
+func (builder *BloomFilterBuilder) Add(passwords ...string) *BloomFilterBuilder {
+	for _, password := range passwords {
+		if password != "" {
+			builder.passwords[password] = struct{}{}
+		}
+	}
+	return builder
+}
+
+// AddWordlist adds every non-empty line of r.
+func (builder *BloomFilterBuilder) AddWordlist(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		builder.Add(strings.TrimRight(scanner.Text(), "\r"))
+	}
+	return scanner.Err()
+}
+
+func (builder *BloomFilterBuilder) AddWordlistFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return builder.AddWordlist(file)
+}
+
+func (builder *BloomFilterBuilder) Build() *BloomFilter {
+	unique := builder.passwords
+	if builder.Normalize {
+		unique = map[string]struct{}{}
+		for password := range builder.passwords {
+			unique[NormalizePassword(password)] = struct{}{}
+		}
+	}
+
+	f := NewBloomFilter(len(unique), builder.FalsePositiveRate)
+	f.Normalized = builder.Normalize
+	for password := range builder.passwords {
+		f.Add(password)
+	}
+	return f
+}
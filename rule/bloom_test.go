@@ -0,0 +1,103 @@
+package rule
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBloomFilter_AddContains(t *testing.T) {
+	f := NewBloomFilter(1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		f.Add("member" + strconv.Itoa(i))
+	}
+	for i := 0; i < 1000; i++ {
+		if !f.Contains("member" + strconv.Itoa(i)) {
+			t.Fatalf("Contains(member%d) = false, want true", i)
+		}
+	}
+
+	falsePositives := 0
+	for i := 0; i < 10000; i++ {
+		if f.Contains("other" + strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / 10000; rate > 0.03 {
+		t.Errorf("false positive rate = %f, want about 0.01", rate)
+	}
+}
+
+func TestBloomFilter_Serialization(t *testing.T) {
+	f := NewBloomFilterBuilder().Add("password", "letmein").Build()
+
+	var buf bytes.Buffer
+	n, err := f.WriteTo(&buf)
+	if err != nil || n != int64(buf.Len()) {
+		t.Fatalf("WriteTo() = %d, %v, want %d, nil", n, err, buf.Len())
+	}
+	read, err := ReadBloomFilter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !read.Normalized || !read.Contains("LetMeIn") {
+		t.Error("read filter should be normalized and contain \"LetMeIn\"")
+	}
+
+	path := filepath.Join(t.TempDir(), "blocklist.bloom")
+	if err := f.WriteFile(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadBloomFilter(path)
+	if err != nil || !loaded.Contains("password") {
+		t.Errorf("LoadBloomFilter() = %v, %v, want a filter containing \"password\"", loaded, err)
+	}
+
+	if _, err := ReadBloomFilter(strings.NewReader("not a filter")); !errors.Is(err, ErrInvalidBloomFilter) {
+		t.Errorf("ReadBloomFilter(garbage) error = %v, want ErrInvalidBloomFilter", err)
+	}
+
+	// Headers claiming more bits than the limit or than the data holds
+	for _, size := range []uint64{maxBloomFilterSize + 1, maxBloomFilterSize} {
+		header := append([]byte(bloomMagic), 1, 0, 0, 0, 7)
+		header = binary.BigEndian.AppendUint64(header, size)
+		if _, err := ReadBloomFilter(bytes.NewReader(append(header, make([]byte, 64)...))); !errors.Is(err, ErrInvalidBloomFilter) {
+			t.Errorf("ReadBloomFilter(size %d) error = %v, want ErrInvalidBloomFilter", size, err)
+		}
+	}
+}
+
+func TestBloomFilterBuilder_Normalization(t *testing.T) {
+	normalized := NewBloomFilterBuilder().Add("password1").Build()
+	for _, variant := range []string{"password1", "PASSWORD1", "P@ssw0rd1", "pa$$word!"} {
+		if !normalized.Contains(variant) {
+			t.Errorf("normalized filter should contain %q", variant)
+		}
+	}
+
+	exact := NewBloomFilterBuilder().WithNormalization(false).Add("password1").Build()
+	if exact.Contains("P@ssw0rd1") {
+		t.Error("filter without normalization should not contain \"P@ssw0rd1\"")
+	}
+}
+
+func TestBloomFilterBuilder_AddWordlist(t *testing.T) {
+	builder := NewBloomFilterBuilder().WithFalsePositiveRate(0.0001)
+	if err := builder.AddWordlist(strings.NewReader("dragon\r\n\r\nmonkey\n")); err != nil {
+		t.Fatal(err)
+	}
+	f := builder.Build()
+	if !f.Contains("dragon") || !f.Contains("monkey") {
+		t.Error("filter should contain every line of the wordlist")
+	}
+}
+
+func TestNormalizePassword(t *testing.T) {
+	if got := NormalizePassword("P@$$w0rd"); got != "password" {
+		t.Errorf("NormalizePassword(%q) = %q, want %q", "P@$$w0rd", got, "password")
+	}
+}
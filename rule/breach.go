@@ -0,0 +1,109 @@
+package rule
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidHashPrefix   = errors.New("hash prefix must be 5 hexadecimal characters")
+	ErrInvalidBreachRecord = errors.New("invalid breach record")
+)
+
+// HashPrefixLength is the number of hexadecimal characters of a password's
+// SHA-1 hash sent to a BreachStore.
+const HashPrefixLength = 5
+
+// BreachStore looks up SHA-1 hashes of breached passwords by the first five
+// hexadecimal characters of the hash, like the Have I Been Pwned range API.
+// Range returns the occurrence count of every known hash with the prefix,
+// keyed by the remaining 35 uppercase hexadecimal characters, so a remote
+// store never learns which password is being checked.
+type BreachStore interface {
+	Range(prefix string) (map[string]int, error)
+}
+
+// BreachCount returns how many times password appears in store.
+func BreachCount(store BreachStore, password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	suffixes, err := store.Range(hash[:HashPrefixLength])
+	if err != nil {
+		return 0, err
+	}
+	return suffixes[hash[HashPrefixLength:]], nil
+}
+
+func validateHashPrefix(prefix string) (string, error) {
+	if len(prefix) != HashPrefixLength {
+		return "", ErrInvalidHashPrefix
+	}
+	if _, err := hex.DecodeString(prefix + "0"); err != nil {
+		return "", ErrInvalidHashPrefix
+	}
+	return strings.ToUpper(prefix), nil
+}
+
+// parseHIBPLine parses a "HASH:COUNT" line as found in HIBP downloads and
+// range API responses.
+func parseHIBPLine(line string) (string, int, error) {
+	hash, count, ok := strings.Cut(strings.TrimSpace(line), ":")
+	if !ok {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidBreachRecord, line)
+	}
+	n, err := strconv.Atoi(count)
+	if err != nil || n < 0 {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidBreachRecord, line)
+	}
+	return strings.ToUpper(hash), n, nil
+}
+
+// BreachedRule rejects passwords found in a BreachStore more than
+// MaxOccurrences times. Lookup failures are returned as errors, so a password
+// is never accepted because the store was unavailable.
+type BreachedRule struct {
+	Store          BreachStore
+	MaxOccurrences int
+}
+
+func NewBreachedRule(store BreachStore) *BreachedRule {
+	return &BreachedRule{Store: store}
+}
+
+func (r *BreachedRule) Name() string {
+	return "not_breached"
+}
+
+func (r *BreachedRule) Validate(password string) (bool, error) {
+	count, err := BreachCount(r.Store, password)
+	if err != nil {
+		return false, fmt.Errorf("breached password lookup failed: %w", err)
+	}
+	if count > r.MaxOccurrences {
+		violation := newViolation(r.Name(), CodeBreached, r.MaxOccurrences, count, map[string]any{"count": count})
+		return false, &violation
+	}
+
+	return true, nil
+}
+
+type BreachedRuleBuilder struct {
+	BreachedRule *BreachedRule
+}
+
+func NewBreachedRuleBuilder(store BreachStore) *BreachedRuleBuilder {
+	return &BreachedRuleBuilder{BreachedRule: NewBreachedRule(store)}
+}
+
+func (builder *BreachedRuleBuilder) WithMaxOccurrences(maxOccurrences int) *BreachedRuleBuilder {
+	builder.BreachedRule.MaxOccurrences = maxOccurrences
+	return builder
+}
+
+func (builder *BreachedRuleBuilder) Build() *BreachedRule {
+	return builder.BreachedRule
+}
@@ -0,0 +1,251 @@
+package rule
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+var ErrUnsortedBreachFile = errors.New("breach file is not sorted by hash")
+
+// HIBPFile is a BreachStore reading a local copy of the Have I Been Pwned
+// password list in its "ordered by hash" text form, one "HASH:COUNT" line per
+// password. Lookups binary search the file, so it is never loaded into
+// memory.
+type HIBPFile struct {
+	file *os.File
+	size int64
+}
+
+func OpenHIBPFile(path string) (*HIBPFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &HIBPFile{file: file, size: info.Size()}, nil
+}
+
+func (h *HIBPFile) Close() error {
+	return h.file.Close()
+}
+
+func (h *HIBPFile) Range(prefix string) (map[string]int, error) {
+	prefix, err := validateHashPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the smallest offset whose next line sorts at or after prefix.
+	low, high := int64(0), h.size
+	for low < high {
+		mid := low + (high-low)/2
+		lineStart, line, err := h.lineAfter(mid)
+		if err != nil {
+			return nil, err
+		}
+		if lineStart >= h.size || strings.ToUpper(line[:min(len(line), HashPrefixLength)]) >= prefix {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	lineStart, _, err := h.lineAfter(low)
+	if err != nil {
+		return nil, err
+	}
+
+	suffixes := map[string]int{}
+	scanner := bufio.NewScanner(io.NewSectionReader(h.file, lineStart, h.size-lineStart))
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		hash, count, err := parseHIBPLine(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(hash, prefix) {
+			break
+		}
+		suffixes[hash[HashPrefixLength:]] = count
+	}
+	return suffixes, scanner.Err()
+}
+
+// lineAfter returns the first line starting at or after offset together with
+// its start. The start equals the file size when there is no such line.
+func (h *HIBPFile) lineAfter(offset int64) (int64, string, error) {
+	const chunk = 256
+
+	start := offset
+	if offset > 0 {
+		// A line starts at offset when the previous byte is a newline.
+		for position := offset - 1; ; position += chunk {
+			if position >= h.size {
+				return h.size, "", nil
+			}
+			buf := make([]byte, chunk)
+			n, err := h.file.ReadAt(buf, position)
+			if err != nil && err != io.EOF {
+				return 0, "", err
+			}
+			if i := bytes.IndexByte(buf[:n], '\n'); i >= 0 {
+				start = position + int64(i) + 1
+				break
+			}
+		}
+	}
+	if start >= h.size {
+		return h.size, "", nil
+	}
+
+	var line []byte
+	buf := make([]byte, chunk)
+	for position := start; position < h.size; position += chunk {
+		n, err := h.file.ReadAt(buf, position)
+		if err != nil && err != io.EOF {
+			return 0, "", err
+		}
+		if i := bytes.IndexByte(buf[:n], '\n'); i >= 0 {
+			line = append(line, buf[:i]...)
+			break
+		}
+		line = append(line, buf[:n]...)
+	}
+	return start, string(line), nil
+}
+
+// breachIndexMagic starts every file written by BuildBreachIndex.
+const breachIndexMagic = "PWIDX1\x00\x00"
+
+const (
+	sha1Size         = 20
+	breachRecordSize = sha1Size + 4
+)
+
+// BuildBreachIndex converts an HIBP "ordered by hash" text file read from r
+// into a compact binary index written to w: fixed-size records of the raw
+// SHA-1 hash followed by a big-endian uint32 count. The index is about half
+// the size of the text file and is read by OpenBreachIndex.
+func BuildBreachIndex(r io.Reader, w io.Writer) error {
+	out := bufio.NewWriter(w)
+	if _, err := out.WriteString(breachIndexMagic); err != nil {
+		return err
+	}
+
+	var previous []byte
+	record := make([]byte, breachRecordSize)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		hash, count, err := parseHIBPLine(scanner.Text())
+		if err != nil {
+			return err
+		}
+		raw, err := hex.DecodeString(hash)
+		if err != nil || len(raw) != sha1Size {
+			return fmt.Errorf("%w: %q", ErrInvalidBreachRecord, scanner.Text())
+		}
+		if previous != nil && bytes.Compare(previous, raw) >= 0 {
+			return fmt.Errorf("%w: %s", ErrUnsortedBreachFile, hash)
+		}
+		previous = raw
+
+		copy(record, raw)
+		binary.BigEndian.PutUint32(record[sha1Size:], uint32(min(uint64(count), math.MaxUint32)))
+		if _, err := out.Write(record); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// BreachIndex is a BreachStore reading an index written by BuildBreachIndex.
+type BreachIndex struct {
+	file    *os.File
+	records int64
+}
+
+func OpenBreachIndex(path string) (*BreachIndex, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	magic := make([]byte, len(breachIndexMagic))
+	if _, err := file.ReadAt(magic, 0); err != nil || string(magic) != breachIndexMagic ||
+		(info.Size()-int64(len(breachIndexMagic)))%breachRecordSize != 0 {
+		file.Close()
+		return nil, fmt.Errorf("%w: %s is not a breach index", ErrInvalidBreachRecord, path)
+	}
+	return &BreachIndex{file: file, records: (info.Size() - int64(len(breachIndexMagic))) / breachRecordSize}, nil
+}
+
+func (b *BreachIndex) Close() error {
+	return b.file.Close()
+}
+
+func (b *BreachIndex) Range(prefix string) (map[string]int, error) {
+	prefix, err := validateHashPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var readErr error
+	first := sort.Search(int(b.records), func(i int) bool {
+		record, err := b.record(int64(i))
+		if err != nil {
+			readErr = err
+			return true
+		}
+		return strings.ToUpper(hex.EncodeToString(record[:3]))[:HashPrefixLength] >= prefix
+	})
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	suffixes := map[string]int{}
+	for i := int64(first); i < b.records; i++ {
+		record, err := b.record(i)
+		if err != nil {
+			return nil, err
+		}
+		hash := strings.ToUpper(hex.EncodeToString(record[:sha1Size]))
+		if !strings.HasPrefix(hash, prefix) {
+			break
+		}
+		suffixes[hash[HashPrefixLength:]] = int(binary.BigEndian.Uint32(record[sha1Size:]))
+	}
+	return suffixes, nil
+}
+
+func (b *BreachIndex) record(i int64) ([]byte, error) {
+	record := make([]byte, breachRecordSize)
+	if _, err := b.file.ReadAt(record, int64(len(breachIndexMagic))+i*breachRecordSize); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
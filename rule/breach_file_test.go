@@ -0,0 +1,104 @@
+package rule
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeHIBPFile(t *testing.T, passwords map[string]int) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pwned-passwords-sha1-ordered-by-hash.txt")
+	if err := os.WriteFile(path, []byte(hibpLines(passwords)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// manyBreaches adds enough entries for the binary searches to take several
+// steps.
+func manyBreaches() map[string]int {
+	passwords := map[string]int{}
+	for password, count := range breachFixture {
+		passwords[password] = count
+	}
+	for i := 0; i < 500; i++ {
+		passwords["filler"+strings.Repeat("x", i%7)+string(rune('a'+i%26))+string(rune('a'+i/26))] = i + 1
+	}
+	return passwords
+}
+
+func checkBreachStore(t *testing.T, store BreachStore, passwords map[string]int) {
+	t.Helper()
+	for password, want := range passwords {
+		if got, err := BreachCount(store, password); err != nil || got != want {
+			t.Fatalf("BreachCount(%q) = %d, %v, want %d, nil", password, got, err, want)
+		}
+	}
+	if got, err := BreachCount(store, "xK9#mQ2$vL7!"); err != nil || got != 0 {
+		t.Errorf("BreachCount(unknown) = %d, %v, want 0, nil", got, err)
+	}
+
+	sum := sha1.Sum([]byte("password"))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	suffixes, err := store.Range(strings.ToLower(hash[:HashPrefixLength]))
+	if err != nil || suffixes[hash[HashPrefixLength:]] != passwords["password"] {
+		t.Errorf("Range(lowercase prefix) = %v, %v", suffixes, err)
+	}
+	if _, err := store.Range("XYZ"); !errors.Is(err, ErrInvalidHashPrefix) {
+		t.Errorf("Range(%q) error = %v, want ErrInvalidHashPrefix", "XYZ", err)
+	}
+}
+
+func TestHIBPFile_Range(t *testing.T) {
+	passwords := manyBreaches()
+	store, err := OpenHIBPFile(writeHIBPFile(t, passwords))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	checkBreachStore(t, store, passwords)
+}
+
+func TestBreachIndex_Range(t *testing.T) {
+	passwords := manyBreaches()
+	var index bytes.Buffer
+	if err := BuildBreachIndex(strings.NewReader(hibpLines(passwords)), &index); err != nil {
+		t.Fatal(err)
+	}
+	if text := len(hibpLines(passwords)); index.Len() >= text {
+		t.Errorf("index is %d bytes, want less than %d", index.Len(), text)
+	}
+
+	path := filepath.Join(t.TempDir(), "pwned.idx")
+	if err := os.WriteFile(path, index.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	store, err := OpenBreachIndex(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	checkBreachStore(t, store, passwords)
+}
+
+func TestBuildBreachIndex_Unsorted(t *testing.T) {
+	input := "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:1\n0000000000000000000000000000000000000000:2\n"
+	if err := BuildBreachIndex(strings.NewReader(input), &bytes.Buffer{}); !errors.Is(err, ErrUnsortedBreachFile) {
+		t.Errorf("BuildBreachIndex() error = %v, want ErrUnsortedBreachFile", err)
+	}
+}
+
+func TestOpenBreachIndex_NotAnIndex(t *testing.T) {
+	path := writeHIBPFile(t, breachFixture)
+	if _, err := OpenBreachIndex(path); !errors.Is(err, ErrInvalidBreachRecord) {
+		t.Errorf("OpenBreachIndex(text file) error = %v, want ErrInvalidBreachRecord", err)
+	}
+}
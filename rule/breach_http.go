@@ -0,0 +1,80 @@
+package rule
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const DefaultHIBPBaseURL = "https://api.pwnedpasswords.com"
+
+// HIBPClient is a BreachStore querying a Have I Been Pwned compatible range
+// API: GET {BaseURL}/range/{prefix} answering "SUFFIX:COUNT" lines. BaseURL
+// can point at a self-hosted mirror.
+type HIBPClient struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	// AddPadding asks the server to pad responses with zero-count entries
+	// so that the response size does not reveal the prefix.
+	AddPadding bool
+	UserAgent  string
+}
+
+func NewHIBPClient(baseURL string) *HIBPClient {
+	if baseURL == "" {
+		baseURL = DefaultHIBPBaseURL
+	}
+	return &HIBPClient{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: 5 * time.Second},
+		UserAgent:  "paswot",
+	}
+}
+
+func (c *HIBPClient) Range(prefix string) (map[string]int, error) {
+	prefix, err := validateHashPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, c.BaseURL+"/range/"+prefix, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.UserAgent)
+	if c.AddPadding {
+		req.Header.Set("Add-Padding", "true")
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("range request for %s failed: %s", prefix, resp.Status)
+	}
+
+	suffixes := map[string]int{}
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		suffix, count, err := parseHIBPLine(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+		// Padding entries have a count of zero.
+		if count > 0 {
+			suffixes[suffix] = count
+		}
+	}
+	return suffixes, scanner.Err()
+}
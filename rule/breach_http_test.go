@@ -0,0 +1,71 @@
+package rule
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newRangeServer serves the HIBP range API for passwords, recording the
+// requested paths.
+func newRangeServer(t *testing.T, passwords map[string]int, requested *[]string) *httptest.Server {
+	t.Helper()
+	lines := strings.Split(strings.TrimSpace(hibpLines(passwords)), "\r\n")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requested = append(*requested, r.URL.Path)
+		prefix, ok := strings.CutPrefix(r.URL.Path, "/range/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		for _, line := range lines {
+			if strings.HasPrefix(line, prefix) {
+				fmt.Fprintf(w, "%s\r\n", line[HashPrefixLength:])
+			}
+		}
+		if r.Header.Get("Add-Padding") == "true" {
+			fmt.Fprint(w, "0000000000000000000000000000000000A:0\r\n")
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHIBPClient_Range(t *testing.T) {
+	var requested []string
+	server := newRangeServer(t, breachFixture, &requested)
+	client := NewHIBPClient(server.URL + "/")
+	client.AddPadding = true
+
+	checkCount := func(password string, want int) {
+		t.Helper()
+		if got, err := BreachCount(client, password); err != nil || got != want {
+			t.Errorf("BreachCount(%q) = %d, %v, want %d, nil", password, got, err, want)
+		}
+	}
+	checkCount("password", breachFixture["password"])
+	checkCount("xK9#mQ2$vL7!", 0)
+
+	if len(requested) != 2 || requested[0] != "/range/5BAA6" {
+		t.Errorf("requested paths = %v, want [/range/5BAA6 ...]", requested)
+	}
+}
+
+func TestHIBPClient_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	if _, err := NewHIBPClient(server.URL).Range("5BAA6"); err == nil {
+		t.Error("Range() should fail on a non-200 response")
+	}
+}
+
+func TestNewHIBPClient_DefaultURL(t *testing.T) {
+	if got := NewHIBPClient("").BaseURL; got != DefaultHIBPBaseURL {
+		t.Errorf("BaseURL = %q, want %q", got, DefaultHIBPBaseURL)
+	}
+}
@@ -0,0 +1,103 @@
+package rule
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// breachFixture maps passwords to their occurrence counts and renders them as
+// an HIBP "ordered by hash" file.
+var breachFixture = map[string]int{
+	"password":   9545824,
+	"Password1!": 255,
+	"123456":     37359195,
+	"hunter2":    17043,
+}
+
+func hibpLines(passwords map[string]int) string {
+	var lines []string
+	for password, count := range passwords {
+		sum := sha1.Sum([]byte(password))
+		lines = append(lines, strings.ToUpper(hex.EncodeToString(sum[:]))+":"+strconv.Itoa(count))
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+type mapBreachStore map[string]int
+
+func (m mapBreachStore) Range(prefix string) (map[string]int, error) {
+	suffixes := map[string]int{}
+	for password, count := range m {
+		sum := sha1.Sum([]byte(password))
+		hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+		if strings.HasPrefix(hash, prefix) {
+			suffixes[hash[HashPrefixLength:]] = count
+		}
+	}
+	return suffixes, nil
+}
+
+type failingBreachStore struct{}
+
+func (failingBreachStore) Range(string) (map[string]int, error) {
+	return nil, errors.New("store unavailable")
+}
+
+func TestBreachCount(t *testing.T) {
+	store := mapBreachStore(breachFixture)
+	for password, want := range breachFixture {
+		if got, err := BreachCount(store, password); err != nil || got != want {
+			t.Errorf("BreachCount(%q) = %d, %v, want %d, nil", password, got, err, want)
+		}
+	}
+	if got, err := BreachCount(store, "xK9#mQ2$vL7!"); err != nil || got != 0 {
+		t.Errorf("BreachCount(unknown) = %d, %v, want 0, nil", got, err)
+	}
+}
+
+func TestBreachedRule_Validate(t *testing.T) {
+	r := NewBreachedRuleBuilder(mapBreachStore(breachFixture)).WithMaxOccurrences(300).Build()
+
+	valid, err := r.Validate("password")
+	if valid || !errors.Is(err, ErrBreached) {
+		t.Errorf("Validate(%q) = %v, %v, want false, ErrBreached", "password", valid, err)
+	}
+	var violation *Violation
+	if errors.As(err, &violation) && violation.Params["count"] != 9545824 {
+		t.Errorf("violation count = %v, want 9545824", violation.Params["count"])
+	}
+
+	if valid, err := r.Validate("Password1!"); !valid || err != nil {
+		t.Errorf("Validate(%q) = %v, %v, want true, nil as it is below MaxOccurrences", "Password1!", valid, err)
+	}
+}
+
+func TestBreachedRule_StoreError(t *testing.T) {
+	r := NewBreachedRule(failingBreachStore{})
+	valid, err := r.Validate("password")
+	if valid || err == nil {
+		t.Fatalf("Validate() = %v, %v, want false and an error", valid, err)
+	}
+
+	report := NewPaswotRuleBuilder().WithBreached(r).Build().Report("password")
+	if codes := report.Codes(); len(codes) != 1 || codes[0] != CodeRuleFailed {
+		t.Errorf("Report codes = %v, want [%s]", codes, CodeRuleFailed)
+	}
+}
+
+func TestValidateHashPrefix(t *testing.T) {
+	if got, err := validateHashPrefix("5baa6"); err != nil || got != "5BAA6" {
+		t.Errorf("validateHashPrefix(%q) = %q, %v, want %q, nil", "5baa6", got, err, "5BAA6")
+	}
+	for _, prefix := range []string{"", "5BAA", "5BAA61", "5BAG6"} {
+		if _, err := validateHashPrefix(prefix); !errors.Is(err, ErrInvalidHashPrefix) {
+			t.Errorf("validateHashPrefix(%q) error = %v, want ErrInvalidHashPrefix", prefix, err)
+		}
+	}
+}
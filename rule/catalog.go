@@ -0,0 +1,196 @@
+package rule
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Translator renders a violation as a human-readable message.
+type Translator interface {
+	Translate(v *Violation) string
+}
+
+type TranslatorFunc func(v *Violation) string
+
+func (f TranslatorFunc) Translate(v *Violation) string {
+	return f(v)
+}
+
+// Catalog holds message templates per locale. Templates reference the
+// violation's Params by name, plus {expected} and {actual}, e.g.
+// "password must contain at least {min} uppercase characters". Values that
+// are Codes themselves, such as the attribute of CodeContainsUserAttribute,
+// are rendered with their own template in the same locale.
+type Catalog struct {
+	mu       sync.RWMutex
+	locales  map[string]map[Code]string
+	Fallback string
+}
+
+func NewCatalog() *Catalog {
+	c := &Catalog{locales: map[string]map[Code]string{}, Fallback: "en"}
+	c.Register("en", englishMessages)
+	c.Register("id", indonesianMessages)
+	return c
+}
+
+// DefaultCatalog is used to build Violation.Message and by Localize.
+var DefaultCatalog = NewCatalog()
+
+// RegisterMessages adds or overrides templates of a locale in DefaultCatalog.
+func RegisterMessages(locale string, messages map[Code]string) {
+	DefaultCatalog.Register(locale, messages)
+}
+
+// Register adds or overrides templates of a locale. Codes missing from a
+// locale fall back to its base language ("de" for "de-AT") and then to the
+// catalog's Fallback locale.
+func (c *Catalog) Register(locale string, messages map[Code]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	locale = normalizeLocale(locale)
+	if c.locales[locale] == nil {
+		c.locales[locale] = map[Code]string{}
+	}
+	for code, message := range messages {
+		c.locales[locale][code] = message
+	}
+}
+
+func (c *Catalog) Translator(locale string) Translator {
+	return TranslatorFunc(func(v *Violation) string {
+		return c.Render(locale, v)
+	})
+}
+
+// Render falls back to v.Message when no locale has a template for v.Code.
+func (c *Catalog) Render(locale string, v *Violation) string {
+	template, ok := c.template(locale, v.Code)
+	if !ok {
+		return v.Message
+	}
+	return renderTemplate(template, v, func(code Code) (string, bool) {
+		return c.template(locale, code)
+	})
+}
+
+func (c *Catalog) template(locale string, code Code) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	locale = normalizeLocale(locale)
+	base, _, _ := strings.Cut(locale, "-")
+	for _, candidate := range []string{locale, base, normalizeLocale(c.Fallback)} {
+		if template, ok := c.locales[candidate][code]; ok {
+			return template, true
+		}
+	}
+	return "", false
+}
+
+func (v *Violation) Translate(t Translator) string {
+	return t.Translate(v)
+}
+
+func (v *Violation) Localize(locale string) string {
+	return DefaultCatalog.Render(locale, v)
+}
+
+func (r *ValidationReport) Translate(t Translator) []string {
+	messages := make([]string, len(r.Violations))
+	for i := range r.Violations {
+		messages[i] = t.Translate(&r.Violations[i])
+	}
+	return messages
+}
+
+func (r *ValidationReport) Localize(locale string) []string {
+	return r.Translate(DefaultCatalog.Translator(locale))
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+func renderTemplate(template string, v *Violation, lookup func(Code) (string, bool)) string {
+	render := func(value any) string {
+		if code, ok := value.(Code); ok {
+			if name, ok := lookup(code); ok {
+				return name
+			}
+		}
+		return fmt.Sprint(value)
+	}
+
+	replacements := []string{"{expected}", render(v.Expected), "{actual}", render(v.Actual)}
+	for key, value := range v.Params {
+		replacements = append(replacements, "{"+key+"}", render(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// newViolation builds a violation whose Message is the English rendering of
+// its code.
+func newViolation(rule string, code Code, expected, actual any, params map[string]any) Violation {
+	v := Violation{Rule: rule, Code: code, Expected: expected, Actual: actual, Params: params}
+	v.Message = renderTemplate(englishMessages[code], &v, func(code Code) (string, bool) {
+		message, ok := englishMessages[code]
+		return message, ok
+	})
+	return v
+}
+
+var englishMessages = map[Code]string{
+	CodeEmpty:                 "password cannot be empty",
+	CodeContainsWhitespace:    "password cannot contain whitespace",
+	CodeTooShort:              "password length must be between {min} and {max}",
+	CodeTooLong:               "password length must be between {min} and {max}",
+	CodeMissingUppercase:      "password must contain at least {min} uppercase characters",
+	CodeMissingLowercase:      "password must contain at least {min} lowercase characters",
+	CodeMissingNumber:         "password must contain at least {min} number characters",
+	CodeMissingSymbol:         "password must contain at least {min} symbol characters",
+	CodeLowEntropy:            "password must have at least {min} bits of entropy",
+	CodeWeakPassword:          "password is too easy to guess, its strength must be at least {min} out of 4",
+	CodeBreached:              "password has appeared in a data breach {count} times and cannot be used",
+	CodeBlocklisted:           "password is too common and cannot be used",
+	CodeContainsUserAttribute: "password cannot contain or resemble your {attribute}",
+	CodePasswordReused:        "password cannot be one of your last {depth} passwords",
+	CodeTooManyRepeats:        "password cannot contain more than {max} identical characters in a row",
+	CodeContainsSequence:      "password cannot contain sequences longer than {max} characters like \"{sequence}\"",
+	CodeKeyboardSequence:      "password cannot contain keyboard patterns longer than {max} characters like \"{sequence}\"",
+	CodeTooFewWords:           "passphrase must contain at least {min} words",
+	CodeMissingClass:          "password must contain at least {min} {class} characters",
+	CodeDisallowedCharacter:   "password cannot contain \"{character}\" (position {position})",
+	CodeAttributeUsername:     "username",
+	CodeAttributeEmail:        "email",
+	CodeAttributeDisplayName:  "display name",
+	CodeAttributeCompanyName:  "company name",
+}
+
+var indonesianMessages = map[Code]string{
+	CodeEmpty:                 "kata sandi tidak boleh kosong",
+	CodeContainsWhitespace:    "kata sandi tidak boleh mengandung spasi",
+	CodeTooShort:              "panjang kata sandi harus antara {min} dan {max} karakter",
+	CodeTooLong:               "panjang kata sandi harus antara {min} dan {max} karakter",
+	CodeMissingUppercase:      "kata sandi harus mengandung minimal {min} huruf besar",
+	CodeMissingLowercase:      "kata sandi harus mengandung minimal {min} huruf kecil",
+	CodeMissingNumber:         "kata sandi harus mengandung minimal {min} angka",
+	CodeMissingSymbol:         "kata sandi harus mengandung minimal {min} simbol",
+	CodeLowEntropy:            "kata sandi harus memiliki entropi minimal {min} bit",
+	CodeWeakPassword:          "kata sandi terlalu mudah ditebak, kekuatannya minimal harus {min} dari 4",
+	CodeBreached:              "kata sandi telah muncul {count} kali dalam kebocoran data dan tidak boleh digunakan",
+	CodeBlocklisted:           "kata sandi terlalu umum dan tidak boleh digunakan",
+	CodeContainsUserAttribute: "kata sandi tidak boleh mengandung atau menyerupai {attribute} Anda",
+	CodePasswordReused:        "kata sandi tidak boleh sama dengan {depth} kata sandi terakhir Anda",
+	CodeTooManyRepeats:        "kata sandi tidak boleh mengandung lebih dari {max} karakter yang sama berturut-turut",
+	CodeContainsSequence:      "kata sandi tidak boleh mengandung urutan lebih dari {max} karakter seperti \"{sequence}\"",
+	CodeKeyboardSequence:      "kata sandi tidak boleh mengandung pola keyboard lebih dari {max} karakter seperti \"{sequence}\"",
+	CodeTooFewWords:           "frasa sandi harus mengandung minimal {min} kata",
+	CodeMissingClass:          "kata sandi harus mengandung minimal {min} karakter {class}",
+	CodeDisallowedCharacter:   "kata sandi tidak boleh mengandung \"{character}\" (posisi {position})",
+	CodeAttributeUsername:     "nama pengguna",
+	CodeAttributeEmail:        "email",
+	CodeAttributeDisplayName:  "nama tampilan",
+	CodeAttributeCompanyName:  "nama perusahaan",
+}
@@ -0,0 +1,66 @@
+package rule
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCatalog_Render(t *testing.T) {
+	catalog := NewCatalog()
+	catalog.Register("de", map[Code]string{
+		CodeTooShort: "Das Passwort muss zwischen {min} und {max} Zeichen lang sein",
+	})
+
+	_, err := NewLengthRule(8, 16).Validate("short")
+	violation := err.(*Violation)
+
+	testCases := []struct {
+		locale string
+		want   string
+	}{
+		{locale: "en", want: "password length must be between 8 and 16"},
+		{locale: "id", want: "panjang kata sandi harus antara 8 dan 16 karakter"},
+		{locale: "id_ID", want: "panjang kata sandi harus antara 8 dan 16 karakter"},
+		{locale: "de-AT", want: "Das Passwort muss zwischen 8 und 16 Zeichen lang sein"},
+		{locale: "ja", want: "password length must be between 8 and 16"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.locale, func(t *testing.T) {
+			if got := catalog.Render(tc.locale, violation); got != tc.want {
+				t.Errorf("Render(%q) = %q, want %q", tc.locale, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCatalog_PlaceholdersAndFallback(t *testing.T) {
+	catalog := NewCatalog()
+	catalog.Register("ja", map[Code]string{
+		CodeMissingUppercase: "大文字を{expected}文字以上含めてください（現在{actual}文字）",
+	})
+
+	violation := &Violation{Code: CodeMissingUppercase, Expected: 2, Actual: 1, Params: map[string]any{"min": 2}}
+	if got, want := violation.Translate(catalog.Translator("ja")), "大文字を2文字以上含めてください（現在1文字）"; got != want {
+		t.Errorf("Translate() = %q, want %q", got, want)
+	}
+
+	unknown := &Violation{Code: "custom", Message: "custom message"}
+	if got := catalog.Render("ja", unknown); got != "custom message" {
+		t.Errorf("Render() for an unknown code = %q, want the violation message", got)
+	}
+}
+
+func TestValidationReport_Localize(t *testing.T) {
+	RegisterMessages("test-locale", map[Code]string{CodeMissingNumber: "needs {min} digit(s)"})
+
+	report := DefaultRule().Report("Abcdefgh!")
+	if got, want := report.Localize("test-locale"), []string{"needs 1 digit(s)"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Localize() = %v, want %v", got, want)
+	}
+
+	custom := TranslatorFunc(func(v *Violation) string { return string(v.Code) })
+	if got, want := report.Translate(custom), []string{"missing_number"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Translate() = %v, want %v", got, want)
+	}
+}
@@ -3,6 +3,7 @@ package rule
 import (
 	"fmt"
 	"strings"
+	"unicode"
 )
 
 type Charset string
@@ -13,17 +14,45 @@ const (
 	Number            Charset = "0123456789"
 	Symbol            Charset = "!@#$%^&*()-_=+[{]};:'\",<.>/?"
 	All               Charset = AlphabetUpperCase + AlphabetLowerCase + Number + Symbol
+	// Ambiguous characters are easily mistaken for one another when read.
+	Ambiguous Charset = "0O1lI"
 )
 
+// CharacterClass is a custom class of characters of which a password must
+// contain at least Min.
+type CharacterClass struct {
+	Name    string
+	Charset Charset
+	Min     int
+}
+
+// CharacterRule requires minimum counts of each character class. By default
+// only the ASCII characters of the Charset constants are counted; with
+// Unicode set, classes follow Unicode categories, so "Ä" is uppercase, "ß"
+// lowercase, "٣" a number and "€" a symbol.
+//
+// AllowedSymbols replaces Symbol as the symbols a password may contain.
+// Excluded characters, the Ambiguous characters when ExcludeAmbiguous is set,
+// and symbols that are not allowed are rejected by Validate and never
+// generated. Classes adds requirements for custom character classes.
 type CharacterRule struct {
-	MinUppercase int
-	MinLowercase int
-	MinNumber    int
-	MinSymbol    int
+	MinUppercase     int
+	MinLowercase     int
+	MinNumber        int
+	MinSymbol        int
+	Unicode          bool
+	AllowedSymbols   Charset
+	Excluded         string
+	ExcludeAmbiguous bool
+	Classes          []CharacterClass
 }
 
 func (c *CharacterRule) Sum() int {
-	return c.MinUppercase + c.MinLowercase + c.MinNumber + c.MinSymbol
+	sum := c.MinUppercase + c.MinLowercase + c.MinNumber + c.MinSymbol
+	for _, class := range c.Classes {
+		sum += class.Min
+	}
+	return sum
 }
 
 func NewCharacterRule(minUpperCase, minLowerCase, minNumber, minSymbol int) *CharacterRule {
@@ -58,10 +87,112 @@ func (builder *CharacterRuleBuilder) WithMinSymbol(minSymbol int) *CharacterRule
 	return builder
 }
 
+func (builder *CharacterRuleBuilder) WithUnicode(unicode bool) *CharacterRuleBuilder {
+	builder.CharacterRule.Unicode = unicode
+	return builder
+}
+
+func (builder *CharacterRuleBuilder) WithAllowedSymbols(allowedSymbols Charset) *CharacterRuleBuilder {
+	builder.CharacterRule.AllowedSymbols = allowedSymbols
+	return builder
+}
+
+func (builder *CharacterRuleBuilder) WithExcluded(excluded string) *CharacterRuleBuilder {
+	builder.CharacterRule.Excluded = excluded
+	return builder
+}
+
+func (builder *CharacterRuleBuilder) WithExcludeAmbiguous(excludeAmbiguous bool) *CharacterRuleBuilder {
+	builder.CharacterRule.ExcludeAmbiguous = excludeAmbiguous
+	return builder
+}
+
+func (builder *CharacterRuleBuilder) WithClass(name string, charset Charset, min int) *CharacterRuleBuilder {
+	builder.CharacterRule.Classes = append(builder.CharacterRule.Classes, CharacterClass{Name: name, Charset: charset, Min: min})
+	return builder
+}
+
 func (builder *CharacterRuleBuilder) Build() *CharacterRule {
 	return builder.CharacterRule
 }
 
+func (c *CharacterRule) Name() string {
+	return "character"
+}
+
+func (c *CharacterRule) GenerationHint() GenerationHint {
+	var required []CharsetRequirement
+	requirements := []CharsetRequirement{
+		{Charset: c.charset(AlphabetUpperCase), Min: c.MinUppercase},
+		{Charset: c.charset(AlphabetLowerCase), Min: c.MinLowercase},
+		{Charset: c.charset(Number), Min: c.MinNumber},
+		{Charset: c.SymbolSet(), Min: c.MinSymbol},
+	}
+	for _, class := range c.Classes {
+		requirements = append(requirements, CharsetRequirement{Charset: c.charset(class.Charset), Min: class.Min})
+	}
+	for _, requirement := range requirements {
+		if requirement.Min > 0 {
+			required = append(required, requirement)
+		}
+	}
+	return GenerationHint{Required: required, Excluded: c.excluded()}
+}
+
+// SymbolSet returns the symbols a password may contain.
+func (c *CharacterRule) SymbolSet() Charset {
+	if c.AllowedSymbols != "" {
+		return c.charset(c.AllowedSymbols)
+	}
+	return c.charset(Symbol)
+}
+
+// excluded returns the characters that cannot be used, including the
+// symbols of Symbol that are not allowed.
+func (c *CharacterRule) excluded() string {
+	excluded := c.Excluded
+	if c.ExcludeAmbiguous {
+		excluded += string(Ambiguous)
+	}
+	if c.AllowedSymbols != "" {
+		for _, char := range Symbol {
+			if !strings.ContainsRune(string(c.AllowedSymbols), char) {
+				excluded += string(char)
+			}
+		}
+	}
+	return excluded
+}
+
+// charset returns charset without the excluded characters.
+func (c *CharacterRule) charset(charset Charset) Charset {
+	excluded := c.excluded()
+	if excluded == "" {
+		return charset
+	}
+	return Charset(strings.Map(func(char rune) rune {
+		if strings.ContainsRune(excluded, char) {
+			return -1
+		}
+		return char
+	}, string(charset)))
+}
+
+// allowed reports whether char may be used. In Unicode mode every symbol
+// outside AllowedSymbols is rejected, not only those of Symbol.
+func (c *CharacterRule) allowed(char rune) bool {
+	if strings.ContainsRune(c.Excluded, char) || c.ExcludeAmbiguous && strings.ContainsRune(string(Ambiguous), char) {
+		return false
+	}
+	if c.AllowedSymbols == "" || strings.ContainsRune(string(c.AllowedSymbols), char) {
+		return true
+	}
+	if c.Unicode {
+		return !isUnicodeSymbol(char)
+	}
+	return !strings.ContainsRune(string(Symbol), char)
+}
+
 func (c *CharacterRule) ToString() string {
 	var text string
 	text += "MinUppercase: " + fmt.Sprintf("%d", c.MinUppercase) + ", "
@@ -72,45 +203,117 @@ func (c *CharacterRule) ToString() string {
 }
 
 func (c *CharacterRule) Validate(password string) (bool, error) {
-	uppercaseCount := 0
-	for _, char := range password {
-		if strings.ContainsRune(string(AlphabetUpperCase), char) {
-			uppercaseCount++
-		}
+	if violations := c.Violations(password); len(violations) > 0 {
+		return false, &violations[0]
+	}
+
+	return true, nil
+}
+
+// CharacterCounts holds the number of characters of each class in a password.
+// Classes holds the counts of the custom classes of the rule, in order.
+type CharacterCounts struct {
+	Uppercase int
+	Lowercase int
+	Number    int
+	Symbol    int
+	Classes   []int
+}
+
+// Counts classifies the characters of password the way Validate does.
+// Characters that are not allowed are not counted.
+func (c *CharacterRule) Counts(password string) CharacterCounts {
+	counts := CharacterCounts{
+		Uppercase: c.count(password, AlphabetUpperCase, unicode.IsUpper),
+		Lowercase: c.count(password, AlphabetLowerCase, unicode.IsLower),
+		Number:    c.count(password, Number, unicode.IsNumber),
+		Symbol:    c.count(password, c.SymbolSet(), isUnicodeSymbol),
 	}
-	if uppercaseCount < c.MinUppercase {
-		return false, fmt.Errorf("password must contain at least %d uppercase characters", c.MinUppercase)
+	for _, class := range c.Classes {
+		counts.Classes = append(counts.Classes, countIn(password, c.charset(class.Charset)))
 	}
+	return counts
+}
 
-	lowercaseCount := 0
-	for _, char := range password {
-		if strings.ContainsRune(string(AlphabetLowerCase), char) {
-			lowercaseCount++
-		}
+// Violations reports every character class below its minimum, in the order
+// uppercase, lowercase, number, symbol and the custom classes, followed by
+// the first character that is not allowed.
+func (c *CharacterRule) Violations(password string) []Violation {
+	var violations []Violation
+	counts := c.Counts(password)
+
+	if counts.Uppercase < c.MinUppercase {
+		violations = append(violations, c.violation(CodeMissingUppercase, c.MinUppercase, counts.Uppercase))
 	}
-	if lowercaseCount < c.MinLowercase {
-		return false, fmt.Errorf("password must contain at least %d lowercase characters", c.MinLowercase)
+
+	if counts.Lowercase < c.MinLowercase {
+		violations = append(violations, c.violation(CodeMissingLowercase, c.MinLowercase, counts.Lowercase))
 	}
 
-	numberCount := 0
-	for _, char := range password {
-		if strings.ContainsRune(string(Number), char) {
-			numberCount++
+	if counts.Number < c.MinNumber {
+		violations = append(violations, c.violation(CodeMissingNumber, c.MinNumber, counts.Number))
+	}
+
+	if counts.Symbol < c.MinSymbol {
+		violations = append(violations, c.violation(CodeMissingSymbol, c.MinSymbol, counts.Symbol))
+	}
+
+	for i, class := range c.Classes {
+		if counts.Classes[i] < class.Min {
+			violations = append(violations, newViolation(c.Name(), CodeMissingClass, class.Min, counts.Classes[i], map[string]any{"min": class.Min, "class": class.Name}))
+		}
+	}
+
+	for position, char := range []rune(password) {
+		if !c.allowed(char) {
+			violations = append(violations, disallowedCharacter(c.Name(), char, position))
+			break
 		}
 	}
-	if numberCount < c.MinNumber {
-		return false, fmt.Errorf("password must contain at least %d number characters", c.MinNumber)
+
+	return violations
+}
+
+// disallowedCharacter reports char at the 0-based position, counted in
+// characters; the message shows it 1-based. Characters that cannot be
+// printed, such as NUL, are shown as their code point, e.g. U+0000.
+func disallowedCharacter(rule string, char rune, position int) Violation {
+	character := string(char)
+	if !unicode.IsPrint(char) {
+		character = fmt.Sprintf("%U", char)
 	}
+	return newViolation(rule, CodeDisallowedCharacter, nil, character, map[string]any{
+		"character": character,
+		"position":  position + 1,
+	})
+}
 
-	symbolCount := 0
+func (c *CharacterRule) violation(code Code, min, actual int) Violation {
+	return newViolation(c.Name(), code, min, actual, map[string]any{"min": min})
+}
+
+// count counts the characters of password in charset, or matching
+// unicodeClass in Unicode mode.
+func (c *CharacterRule) count(password string, charset Charset, unicodeClass func(rune) bool) int {
+	if !c.Unicode {
+		return countIn(password, c.charset(charset))
+	}
+
+	count := 0
 	for _, char := range password {
-		if strings.ContainsRune(string(Symbol), char) {
-			symbolCount++
+		if unicodeClass(char) && c.allowed(char) {
+			count++
 		}
 	}
-	if symbolCount < c.MinSymbol {
-		return false, fmt.Errorf("password must contain at least %d symbol characters", c.MinSymbol)
-	}
+	return count
+}
 
-	return true, nil
+func countIn(password string, charset Charset) int {
+	count := 0
+	for _, char := range password {
+		if strings.ContainsRune(string(charset), char) {
+			count++
+		}
+	}
+	return count
 }
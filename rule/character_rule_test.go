@@ -1,6 +1,7 @@
 package rule
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -132,3 +133,103 @@ func TestCharacterRule_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestCharacterRule_Unicode(t *testing.T) {
+	password := "Äßé٣€"
+	ascii := NewCharacterRule(1, 1, 1, 1)
+	if _, err := ascii.Validate(password); err == nil {
+		t.Error("Validate() without Unicode should not count non-ASCII characters")
+	}
+
+	rule := NewCharacterRuleBuilder().
+		WithMinUppercase(1).
+		WithMinLowercase(2).
+		WithMinNumber(1).
+		WithMinSymbol(1).
+		WithUnicode(true).
+		Build()
+	if _, err := rule.Validate(password); err != nil {
+		t.Errorf("Validate() with Unicode error = %v, want nil", err)
+	}
+
+	if _, err := rule.Validate("äßé٣€"); err == nil {
+		t.Error("Validate() with Unicode should require an uppercase character")
+	}
+}
+
+func TestCharacterRule_Counts(t *testing.T) {
+	want := CharacterCounts{Uppercase: 2, Lowercase: 3, Number: 1, Symbol: 2}
+	if got := NewCharacterRule(0, 0, 0, 0).Counts("ABcde1!?Ä"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Counts() = %+v, want %+v", got, want)
+	}
+
+	want = CharacterCounts{Uppercase: 3, Lowercase: 3, Number: 1, Symbol: 2}
+	if got := NewCharacterRuleBuilder().WithUnicode(true).Build().Counts("ABcde1!?Ä"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Counts() in Unicode mode = %+v, want %+v", got, want)
+	}
+}
+
+func TestCharacterRule_Charsets(t *testing.T) {
+	rule := NewCharacterRuleBuilder().
+		WithMinUppercase(1).
+		WithMinSymbol(1).
+		WithAllowedSymbols("!#%~").
+		WithExcluded("Q").
+		WithExcludeAmbiguous(true).
+		WithClass("vowel", "aeiou", 2).
+		Build()
+
+	if rule.Sum() != 4 {
+		t.Errorf("Sum() = %d, want 4", rule.Sum())
+	}
+	if got := rule.SymbolSet(); got != "!#%~" {
+		t.Errorf("SymbolSet() = %q, want %q", got, "!#%~")
+	}
+
+	testCases := []struct {
+		name     string
+		password string
+		codes    []Code
+		position int
+	}{
+		{"Valid", "Abcdeo~2", nil, 0},
+		{"Symbol not allowed", "Abcdeo~<", []Code{CodeDisallowedCharacter}, 8},
+		{"Only disallowed symbol", "Abcdeo<", []Code{CodeMissingSymbol, CodeDisallowedCharacter}, 7},
+		{"Excluded uppercase", "Qbcdeo!", []Code{CodeMissingUppercase, CodeDisallowedCharacter}, 1},
+		{"Ambiguous", "Abcde0o!", []Code{CodeDisallowedCharacter}, 6},
+		{"Missing class", "Abcd!", []Code{CodeMissingClass}, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			violations := rule.Violations(tc.password)
+			var codes []Code
+			for _, violation := range violations {
+				codes = append(codes, violation.Code)
+				if violation.Code == CodeDisallowedCharacter && violation.Params["position"] != tc.position {
+					t.Errorf("Violations() position = %v, want %d", violation.Params["position"], tc.position)
+				}
+			}
+			if !reflect.DeepEqual(codes, tc.codes) {
+				t.Errorf("Violations(%q) = %v, want %v", tc.password, codes, tc.codes)
+			}
+		})
+	}
+
+	hint := rule.GenerationHint()
+	for _, char := range "Q0O1lI<'\"" {
+		if !strings.ContainsRune(hint.Excluded, char) {
+			t.Errorf("GenerationHint().Excluded = %q, missing %q", hint.Excluded, char)
+		}
+	}
+	for _, requirement := range hint.Required {
+		if strings.ContainsAny(string(requirement.Charset), "Q0O1lI<") {
+			t.Errorf("GenerationHint() requirement %q contains excluded characters", requirement.Charset)
+		}
+	}
+
+	violation := rule.Violations("Abcdeo~<")[0]
+	if violation.Message != `password cannot contain "<" (position 8)` {
+		t.Errorf("Message = %q", violation.Message)
+	}
+}
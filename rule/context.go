@@ -0,0 +1,81 @@
+package rule
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ValidationContext describes the account a password is validated for.
+// UserID identifies the account in a HistoryStore.
+type ValidationContext struct {
+	UserID      string
+	Username    string
+	Email       string
+	DisplayName string
+	CompanyName string
+}
+
+// ContextRule is a rule that needs the ValidationContext. ValidateWithContext
+// must accept a nil context.
+type ContextRule interface {
+	Rule
+	ValidateWithContext(password string, ctx *ValidationContext) (bool, error)
+}
+
+// ValidateRule validates password with r, passing ctx to context rules.
+func ValidateRule(r Rule, password string, ctx *ValidationContext) (bool, error) {
+	if contextRule, ok := r.(ContextRule); ok {
+		return contextRule.ValidateWithContext(password, ctx)
+	}
+	return r.Validate(password)
+}
+
+type userAttribute struct {
+	name  Code
+	value string
+}
+
+func (ctx *ValidationContext) attributes() []userAttribute {
+	if ctx == nil {
+		return nil
+	}
+
+	localPart, domain, _ := strings.Cut(ctx.Email, "@")
+	domainName, _, _ := strings.Cut(domain, ".")
+	var attributes []userAttribute
+	for _, attribute := range []userAttribute{
+		{CodeAttributeUsername, ctx.Username},
+		{CodeAttributeEmail, localPart},
+		{CodeAttributeEmail, domainName},
+		{CodeAttributeDisplayName, ctx.DisplayName},
+		{CodeAttributeCompanyName, ctx.CompanyName},
+	} {
+		if strings.TrimSpace(attribute.value) != "" {
+			attributes = append(attributes, attribute)
+		}
+	}
+	return attributes
+}
+
+// Values returns the non-empty attributes, e.g. as user inputs for
+// EstimateStrength.
+func (ctx *ValidationContext) Values() []string {
+	var values []string
+	for _, attribute := range ctx.attributes() {
+		values = append(values, attribute.value)
+		values = append(values, attributeTokens(attribute.value)...)
+	}
+	return values
+}
+
+// attributeTokens splits value into its words, e.g. "Jane Doe-Smith" into
+// "jane", "doe" and "smith", plus the words joined together.
+func attributeTokens(value string) []string {
+	words := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > 1 {
+		words = append(words, strings.Join(words, ""))
+	}
+	return words
+}
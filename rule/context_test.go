@@ -0,0 +1,47 @@
+package rule
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidationContext_Values(t *testing.T) {
+	ctx := &ValidationContext{Username: "jdoe", Email: "jane.doe@acme.com"}
+	want := []string{"jdoe", "jdoe", "jane.doe", "jane", "doe", "janedoe", "acme", "acme"}
+	if got := ctx.Values(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+
+	var nilCtx *ValidationContext
+	if got := nilCtx.Values(); got != nil {
+		t.Errorf("nil Values() = %v, want nil", got)
+	}
+}
+
+func TestPaswotRule_ReportWithContext(t *testing.T) {
+	paswotRule := NewPaswotRuleBuilder().
+		WithLength(NewLengthRule(8, 32)).
+		WithUserAttribute(NewUserAttributeRule()).
+		Build()
+	ctx := &ValidationContext{Username: "wissensalt"}
+
+	if report := paswotRule.Report("Wissensalt#2024"); !report.Valid() {
+		t.Errorf("Report() without context = %v, want valid", report.Codes())
+	}
+	report := paswotRule.ReportWithContext("Wissensalt#2024", ctx)
+	if !errors.Is(report, ErrContainsUserAttribute) {
+		t.Errorf("ReportWithContext() codes = %v, want %s", report.Codes(), CodeContainsUserAttribute)
+	}
+}
+
+func TestMinStrengthRule_ValidateWithContext(t *testing.T) {
+	r := NewMinStrengthRule(3)
+	password := "wissensalt#2024"
+	if valid, err := r.Validate(password); !valid {
+		t.Fatalf("Validate(%q) = %v, %v, want true without context", password, valid, err)
+	}
+	if valid, err := r.ValidateWithContext(password, &ValidationContext{Username: "wissensalt"}); valid || !errors.Is(err, ErrWeakPassword) {
+		t.Errorf("ValidateWithContext(%q) = %v, %v, want false, ErrWeakPassword", password, valid, err)
+	}
+}
@@ -0,0 +1,62 @@
+package rule
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// The embedded word lists are frequency-ranked lists from zxcvbn, see
+// data/LICENSE.
+var (
+	//go:embed data/passwords.txt
+	passwordsList string
+	//go:embed data/english.txt
+	englishList string
+	//go:embed data/names.txt
+	namesList string
+	//go:embed data/surnames.txt
+	surnamesList string
+)
+
+const (
+	DictionaryPasswords  = "passwords"
+	DictionaryEnglish    = "english"
+	DictionaryNames      = "names"
+	DictionarySurnames   = "surnames"
+	DictionaryUserInputs = "user_inputs"
+)
+
+// rankedDictionary maps a lowercase word to its frequency rank, starting at 1
+// for the most common word.
+type rankedDictionary struct {
+	name      string
+	ranks     map[string]int
+	maxLength int
+}
+
+func newRankedDictionary(name string, words []string) *rankedDictionary {
+	d := &rankedDictionary{name: name, ranks: make(map[string]int, len(words))}
+	for _, word := range words {
+		word = strings.Map(unicode.ToLower, strings.TrimSpace(word))
+		if word == "" {
+			continue
+		}
+		if _, ok := d.ranks[word]; ok {
+			continue
+		}
+		d.ranks[word] = len(d.ranks) + 1
+		d.maxLength = max(d.maxLength, len([]rune(word)))
+	}
+	return d
+}
+
+var defaultDictionaries = sync.OnceValue(func() []*rankedDictionary {
+	return []*rankedDictionary{
+		newRankedDictionary(DictionaryPasswords, strings.Split(passwordsList, "\n")),
+		newRankedDictionary(DictionaryEnglish, strings.Split(englishList, "\n")),
+		newRankedDictionary(DictionaryNames, strings.Split(namesList, "\n")),
+		newRankedDictionary(DictionarySurnames, strings.Split(surnamesList, "\n")),
+	}
+})
@@ -0,0 +1,159 @@
+package rule
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// otherPoolSize is the pool size assumed for characters outside the ASCII
+// classes, e.g. letters with diacritics or emoji.
+const otherPoolSize = 100
+
+// minDictionaryWordLength is the shortest dictionary word EstimateEntropy
+// discounts; nearly every short string is some word.
+const minDictionaryWordLength = 3
+
+// EstimateEntropy returns the estimated bits of entropy of password. Each
+// character contributes log2 of the pool formed by the character classes
+// present in the password, except characters continuing a pattern: a run of
+// one repeated character contributes log2 of the pool times the run length,
+// and a step of an ascending or descending sequence ("abc", "321") 2 bits.
+// Words of the embedded dictionaries, also reversed or with l33t
+// substitutions, contribute log2 of their guesses instead, based on their
+// frequency rank and capitalization, so "Password1!" scores far lower than
+// random characters of the same length. Like EstimateStrength, only the first
+// 256 characters are considered. See EstimateStrength for a complete pattern
+// analysis.
+func EstimateEntropy(password string) float64 {
+	runes := []rune(password)
+	if len(runes) > maxStrengthLength {
+		runes = runes[:maxStrengthLength]
+	}
+	if len(runes) == 0 {
+		return 0
+	}
+
+	perChar := math.Log2(float64(poolSize(runes)))
+	charBits := make([]float64, len(runes))
+	for i, char := range runes {
+		if i > 0 && isSequenceStep(runes[i-1], char) {
+			charBits[i] = 2
+		} else {
+			charBits[i] = perChar
+		}
+	}
+
+	// Dictionary words by the index of their last character
+	dictionaries := defaultDictionaries()
+	var matches []Match
+	matches = append(matches, dictionaryMatches(runes, dictionaries)...)
+	matches = append(matches, reverseDictionaryMatches(runes, dictionaries)...)
+	matches = append(matches, l33tMatches(runes, dictionaries)...)
+	words := make([][]Match, len(runes))
+	for _, word := range matches {
+		if word.J-word.I+1 >= minDictionaryWordLength {
+			words[word.J] = append(words[word.J], word)
+		}
+	}
+
+	// bits[i] is the lowest estimate for the first i characters, covering
+	// each with its character bits, a run of one character or a dictionary
+	// word ending there.
+	bits := make([]float64, len(runes)+1)
+	runStart := 0
+	for i := 1; i <= len(runes); i++ {
+		if i > 1 && runes[i-1] != runes[i-2] {
+			runStart = i - 1
+		}
+		bits[i] = bits[i-1] + charBits[i-1]
+		if length := i - runStart; length > 1 {
+			bits[i] = min(bits[i], bits[runStart]+math.Log2(float64(length))+perChar)
+		}
+		for _, word := range words[i-1] {
+			bits[i] = min(bits[i], bits[word.I]+math.Log2(dictionaryGuesses(word)))
+		}
+	}
+	return bits[len(runes)]
+}
+
+// CharsetEntropy returns the bits of entropy of length characters drawn
+// uniformly from charset.
+func CharsetEntropy(charset Charset, length int) float64 {
+	size := len([]rune(string(charset)))
+	if size == 0 || length <= 0 {
+		return 0
+	}
+	return float64(length) * math.Log2(float64(size))
+}
+
+func poolSize(runes []rune) int {
+	var upper, lower, number, symbol, other bool
+	for _, char := range runes {
+		switch {
+		case strings.ContainsRune(string(AlphabetUpperCase), char):
+			upper = true
+		case strings.ContainsRune(string(AlphabetLowerCase), char):
+			lower = true
+		case strings.ContainsRune(string(Number), char):
+			number = true
+		case strings.ContainsRune(string(Symbol), char), char == ' ':
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	size := 0
+	for _, class := range []struct {
+		present bool
+		size    int
+	}{
+		{upper, len(AlphabetUpperCase)},
+		{lower, len(AlphabetLowerCase)},
+		{number, len(Number)},
+		{symbol, len(Symbol) + 1},
+		{other, otherPoolSize},
+	} {
+		if class.present {
+			size += class.size
+		}
+	}
+	return size
+}
+
+func isSequenceStep(previous, char rune) bool {
+	if !unicode.IsLetter(char) && !unicode.IsDigit(char) {
+		return false
+	}
+	diff := unicode.ToLower(char) - unicode.ToLower(previous)
+	return diff == 1 || diff == -1
+}
+
+type MinEntropyRule struct {
+	MinBits float64
+}
+
+func NewMinEntropyRule(minBits float64) *MinEntropyRule {
+	return &MinEntropyRule{MinBits: minBits}
+}
+
+func (r *MinEntropyRule) Name() string {
+	return "min_entropy"
+}
+
+func (r *MinEntropyRule) Validate(password string) (bool, error) {
+	if violations := r.Violations(password); len(violations) > 0 {
+		return false, &violations[0]
+	}
+
+	return true, nil
+}
+
+func (r *MinEntropyRule) Violations(password string) []Violation {
+	if bits := EstimateEntropy(password); bits < r.MinBits {
+		return []Violation{newViolation(r.Name(), CodeLowEntropy, r.MinBits, math.Floor(bits), map[string]any{"min": r.MinBits})}
+	}
+
+	return nil
+}
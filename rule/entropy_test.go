@@ -0,0 +1,80 @@
+package rule
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestEstimateEntropy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		password string
+		want     float64
+	}{
+		{name: "Empty", password: "", want: 0},
+		{name: "Lowercase", password: "xkqz", want: 4 * math.Log2(26)},
+		{name: "Mixed classes", password: "qX7!", want: 4 * math.Log2(26+26+10+float64(len(Symbol))+1)},
+		{name: "Repeat", password: "aaaa", want: math.Log2(26 * 4)},
+		{name: "Ascending sequence", password: "vwxy", want: math.Log2(26) + 3*2},
+		{name: "Descending digits", password: "7654", want: math.Log2(10) + 3*2},
+		// "password" ranks first in the passwords dictionary: a single guess
+		{name: "Dictionary word", password: "password", want: 0},
+		{name: "Capitalized word and suffix", password: "Password1!", want: 1 + 2*math.Log2(26+26+10+float64(len(Symbol))+1)},
+		{name: "Reversed word", password: "drowssap", want: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := EstimateEntropy(tc.password); math.Abs(got-tc.want) > 1e-9 {
+				t.Errorf("EstimateEntropy(%q) = %f, want %f", tc.password, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEstimateEntropy_LongPassword(t *testing.T) {
+	password := strings.Repeat("Xk9#mQ2$vL7p", 10_000)
+	if got, want := EstimateEntropy(password), EstimateEntropy(password[:maxStrengthLength]); got != want {
+		t.Errorf("EstimateEntropy(long) = %f, want %f for its first %d characters", got, want, maxStrengthLength)
+	}
+}
+
+func TestCharsetEntropy(t *testing.T) {
+	if got, want := CharsetEntropy(Number, 4), 4*math.Log2(10); math.Abs(got-want) > 1e-9 {
+		t.Errorf("CharsetEntropy(Number, 4) = %f, want %f", got, want)
+	}
+	if got := CharsetEntropy("", 4); got != 0 {
+		t.Errorf("CharsetEntropy(\"\", 4) = %f, want 0", got)
+	}
+}
+
+func TestMinEntropyRule_Validate(t *testing.T) {
+	minEntropy := NewMinEntropyRule(50)
+
+	if _, err := minEntropy.Validate("Xk9#mQ2$vL7p"); err != nil {
+		t.Errorf("Validate() with a random password error = %v", err)
+	}
+
+	if _, err := minEntropy.Validate("Password1!"); !errors.Is(err, ErrLowEntropy) {
+		t.Errorf("Validate() with a dictionary word error = %v, want %v", err, ErrLowEntropy)
+	}
+
+	_, err := minEntropy.Validate("Aaaaaaa1!")
+	if !errors.Is(err, ErrLowEntropy) {
+		t.Fatalf("Validate() error = %v, want %v", err, ErrLowEntropy)
+	}
+	if want := "password must have at least 50 bits of entropy"; err.Error() != want {
+		t.Errorf("Validate() error = %q, want %q", err.Error(), want)
+	}
+
+	if _, err := NewMinEntropyRule(80).Validate(strings.Repeat("a", 80)); !errors.Is(err, ErrLowEntropy) {
+		t.Errorf("Validate() with a single repeated character error = %v, want %v", err, ErrLowEntropy)
+	}
+
+	paswotRule := NewPaswotRuleBuilder().WithMinEntropy(minEntropy).Build()
+	if paswotRule.Rules[0] != minEntropy {
+		t.Error("WithMinEntropy did not add the rule")
+	}
+}
@@ -0,0 +1,76 @@
+package rule
+
+import (
+	"fmt"
+)
+
+// Matcher reports whether hashed was produced from the password the matcher
+// was created for. paswot.Paswot and its salted variants implement it.
+type Matcher interface {
+	Match(hashed string) bool
+}
+
+// HistoryRule rejects a password matching one of the last Depth hashes stored
+// for ValidationContext.UserID. NewMatcher wraps a candidate password in a
+// Matcher, e.g. a paswot.Paswot built with the same salt and pepper as the
+// stored hashes. Without a user ID there is no history to compare with and
+// every password is accepted.
+type HistoryRule struct {
+	Store      HistoryStore
+	Depth      int
+	NewMatcher func(password string) Matcher
+}
+
+// DefaultHistoryDepth is the number of previous passwords that cannot be
+// reused, as required by PCI DSS.
+const DefaultHistoryDepth = 4
+
+func NewHistoryRule(store HistoryStore, newMatcher func(password string) Matcher) *HistoryRule {
+	return &HistoryRule{Store: store, Depth: DefaultHistoryDepth, NewMatcher: newMatcher}
+}
+
+func (r *HistoryRule) Name() string {
+	return "history"
+}
+
+func (r *HistoryRule) Validate(password string) (bool, error) {
+	return r.ValidateWithContext(password, nil)
+}
+
+func (r *HistoryRule) ValidateWithContext(password string, ctx *ValidationContext) (bool, error) {
+	if ctx == nil || ctx.UserID == "" || r.Depth <= 0 {
+		return true, nil
+	}
+
+	hashes, err := r.Store.Recent(ctx.UserID, r.Depth)
+	if err != nil {
+		return false, fmt.Errorf("password history lookup failed: %w", err)
+	}
+
+	matcher := r.NewMatcher(password)
+	for i, hashed := range hashes {
+		if matcher.Match(hashed) {
+			violation := newViolation(r.Name(), CodePasswordReused, r.Depth, i+1, map[string]any{"depth": r.Depth})
+			return false, &violation
+		}
+	}
+
+	return true, nil
+}
+
+type HistoryRuleBuilder struct {
+	HistoryRule *HistoryRule
+}
+
+func NewHistoryRuleBuilder(store HistoryStore, newMatcher func(password string) Matcher) *HistoryRuleBuilder {
+	return &HistoryRuleBuilder{HistoryRule: NewHistoryRule(store, newMatcher)}
+}
+
+func (builder *HistoryRuleBuilder) WithDepth(depth int) *HistoryRuleBuilder {
+	builder.HistoryRule.Depth = depth
+	return builder
+}
+
+func (builder *HistoryRuleBuilder) Build() *HistoryRule {
+	return builder.HistoryRule
+}
@@ -0,0 +1,67 @@
+package rule
+
+import (
+	"errors"
+	"testing"
+)
+
+// plainMatcher matches hashes of the form "hash:<password>".
+type plainMatcher string
+
+func (m plainMatcher) Match(hashed string) bool {
+	return hashed == "hash:"+string(m)
+}
+
+func newPlainMatcher(password string) Matcher {
+	return plainMatcher(password)
+}
+
+type failingHistoryStore struct{}
+
+func (failingHistoryStore) Add(string, string) error {
+	return errors.New("store unavailable")
+}
+
+func (failingHistoryStore) Recent(string, int) ([]string, error) {
+	return nil, errors.New("store unavailable")
+}
+
+func TestHistoryRule_ValidateWithContext(t *testing.T) {
+	store := NewMemoryHistoryStore(0)
+	for _, password := range []string{"first", "second", "third", "fourth", "fifth"} {
+		_ = store.Add("user-1", "hash:"+password)
+	}
+	r := NewHistoryRuleBuilder(store, newPlainMatcher).WithDepth(4).Build()
+	ctx := &ValidationContext{UserID: "user-1"}
+
+	valid, err := r.ValidateWithContext("second", ctx)
+	if valid || !errors.Is(err, ErrPasswordReused) {
+		t.Fatalf("ValidateWithContext(%q) = %v, %v, want false, ErrPasswordReused", "second", valid, err)
+	}
+	var violation *Violation
+	if errors.As(err, &violation) && (violation.Params["depth"] != 4 || violation.Actual != 4) {
+		t.Errorf("violation = %+v, want depth 4 and the password 4th most recent", violation)
+	}
+
+	// "first" is the fifth most recent password, beyond the depth.
+	if valid, err := r.ValidateWithContext("first", ctx); !valid || err != nil {
+		t.Errorf("ValidateWithContext(%q) = %v, %v, want true, nil", "first", valid, err)
+	}
+	if valid, err := r.ValidateWithContext("second", &ValidationContext{UserID: "user-2"}); !valid || err != nil {
+		t.Errorf("ValidateWithContext(other user) = %v, %v, want true, nil", valid, err)
+	}
+}
+
+func TestHistoryRule_NoUser(t *testing.T) {
+	r := NewHistoryRule(failingHistoryStore{}, newPlainMatcher)
+	if valid, err := r.Validate("second"); !valid || err != nil {
+		t.Errorf("Validate() without context = %v, %v, want true, nil", valid, err)
+	}
+}
+
+func TestHistoryRule_StoreError(t *testing.T) {
+	r := NewHistoryRule(failingHistoryStore{}, newPlainMatcher)
+	if valid, err := r.ValidateWithContext("second", &ValidationContext{UserID: "user-1"}); valid || err == nil {
+		t.Errorf("ValidateWithContext() = %v, %v, want false and an error", valid, err)
+	}
+}
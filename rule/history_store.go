@@ -0,0 +1,174 @@
+package rule
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var ErrInvalidTableName = errors.New("invalid table name")
+
+// HistoryStore keeps the hashes of the passwords a user had.
+type HistoryStore interface {
+	// Add records hashed as the user's newest password.
+	Add(userID, hashed string) error
+	// Recent returns up to n of the user's hashes, newest first.
+	Recent(userID string, n int) ([]string, error)
+}
+
+// MemoryHistoryStore is a HistoryStore for tests and single-process
+// deployments. When Limit is positive only the newest Limit hashes per user
+// are kept.
+type MemoryHistoryStore struct {
+	Limit int
+
+	mu     sync.RWMutex
+	hashes map[string][]string
+}
+
+func NewMemoryHistoryStore(limit int) *MemoryHistoryStore {
+	return &MemoryHistoryStore{Limit: limit, hashes: map[string][]string{}}
+}
+
+func (s *MemoryHistoryStore) Add(userID, hashed string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.hashes == nil {
+		s.hashes = map[string][]string{}
+	}
+	hashes := append([]string{hashed}, s.hashes[userID]...)
+	if s.Limit > 0 && len(hashes) > s.Limit {
+		hashes = hashes[:s.Limit]
+	}
+	s.hashes[userID] = hashes
+	return nil
+}
+
+func (s *MemoryHistoryStore) Recent(userID string, n int) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	hashes := s.hashes[userID]
+	return append([]string(nil), hashes[:min(max(n, 0), len(hashes))]...), nil
+}
+
+// SQLHistoryStore is a HistoryStore backed by a table created with Schema:
+//
+//	CREATE TABLE password_history (
+//	    user_id       VARCHAR(255) NOT NULL,
+//	    password_hash VARCHAR(255) NOT NULL,
+//	    created_at    BIGINT       NOT NULL
+//	)
+//
+// created_at holds Unix nanoseconds, which keeps the queries portable across
+// databases. Placeholder renders the n-th (1-based) query parameter; the
+// default "?" suits MySQL and SQLite, DollarPlaceholder suits PostgreSQL.
+// A store built as a struct literal uses the defaults as well, and its Table
+// is checked like NewSQLHistoryStore's before every query.
+type SQLHistoryStore struct {
+	DB          *sql.DB
+	Table       string
+	Placeholder func(n int) string
+	now         func() time.Time
+}
+
+var tableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+func NewSQLHistoryStore(db *sql.DB, table string) (*SQLHistoryStore, error) {
+	store := &SQLHistoryStore{DB: db, Table: table, Placeholder: QuestionPlaceholder}
+	table, err := store.table()
+	if err != nil {
+		return nil, err
+	}
+	store.Table = table
+	return store, nil
+}
+
+func QuestionPlaceholder(int) string {
+	return "?"
+}
+
+func DollarPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// Schema returns the statement creating the history table, or an empty
+// string when Table is not a valid table name.
+func (s *SQLHistoryStore) Schema() string {
+	table, err := s.table()
+	if err != nil {
+		return ""
+	}
+	return "CREATE TABLE " + table + " (user_id VARCHAR(255) NOT NULL, password_hash VARCHAR(255) NOT NULL, created_at BIGINT NOT NULL)"
+}
+
+// table returns Table, password_history when empty. The table name is part
+// of the query text, so it must not contain anything but an identifier.
+func (s *SQLHistoryStore) table() (string, error) {
+	if s.Table == "" {
+		return "password_history", nil
+	}
+	if !tableNamePattern.MatchString(s.Table) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidTableName, s.Table)
+	}
+	return s.Table, nil
+}
+
+func (s *SQLHistoryStore) placeholder(n int) string {
+	if s.Placeholder == nil {
+		return QuestionPlaceholder(n)
+	}
+	return s.Placeholder(n)
+}
+
+func (s *SQLHistoryStore) currentTime() time.Time {
+	if s.now == nil {
+		return time.Now()
+	}
+	return s.now()
+}
+
+func (s *SQLHistoryStore) Add(userID, hashed string) error {
+	table, err := s.table()
+	if err != nil {
+		return err
+	}
+
+	query := "INSERT INTO " + table + " (user_id, password_hash, created_at) VALUES (" +
+		s.placeholder(1) + ", " + s.placeholder(2) + ", " + s.placeholder(3) + ")"
+	_, err = s.DB.Exec(query, userID, hashed, s.currentTime().UnixNano())
+	return err
+}
+
+// Recent returns no hashes for n of zero or less without querying, as some
+// databases read a negative LIMIT as no limit.
+func (s *SQLHistoryStore) Recent(userID string, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	table, err := s.table()
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT password_hash FROM " + table + " WHERE user_id = " + s.placeholder(1) +
+		" ORDER BY created_at DESC LIMIT " + s.placeholder(2)
+	rows, err := s.DB.Query(query, userID, n)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var hashed string
+		if err := rows.Scan(&hashed); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hashed)
+	}
+	return hashes, rows.Err()
+}
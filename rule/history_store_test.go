@@ -0,0 +1,110 @@
+package rule
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestMemoryHistoryStore(t *testing.T) {
+	store := NewMemoryHistoryStore(3)
+	for _, hashed := range []string{"h1", "h2", "h3", "h4"} {
+		if err := store.Add("user-1", hashed); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got, _ := store.Recent("user-1", 10); !reflect.DeepEqual(got, []string{"h4", "h3", "h2"}) {
+		t.Errorf("Recent(10) = %v, want [h4 h3 h2]", got)
+	}
+	if got, _ := store.Recent("user-1", 2); !reflect.DeepEqual(got, []string{"h4", "h3"}) {
+		t.Errorf("Recent(2) = %v, want [h4 h3]", got)
+	}
+	if got, _ := store.Recent("user-2", 2); len(got) != 0 {
+		t.Errorf("Recent(unknown user) = %v, want empty", got)
+	}
+	if got, err := store.Recent("user-1", -1); len(got) != 0 || err != nil {
+		t.Errorf("Recent(-1) = %v, %v, want empty, nil", got, err)
+	}
+}
+
+func TestSQLHistoryStore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store, err := NewSQLHistoryStore(db, "auth.password_history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Placeholder = DollarPlaceholder
+	store.now = func() time.Time { return time.Unix(0, 42) }
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO auth.password_history (user_id, password_hash, created_at) VALUES ($1, $2, $3)")).
+		WithArgs("user-1", "h1", int64(42)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT password_hash FROM auth.password_history WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2")).
+		WithArgs("user-1", 4).
+		WillReturnRows(sqlmock.NewRows([]string{"password_hash"}).AddRow("h2").AddRow("h1"))
+
+	if err := store.Add("user-1", "h1"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	got, err := store.Recent("user-1", 4)
+	if err != nil || !reflect.DeepEqual(got, []string{"h2", "h1"}) {
+		t.Errorf("Recent() = %v, %v, want [h2 h1], nil", got, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSQLHistoryStore_StructLiteral(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := &SQLHistoryStore{DB: db}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO password_history (user_id, password_hash, created_at) VALUES (?, ?, ?)")).
+		WithArgs("user-1", "h1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := store.Add("user-1", "h1"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if got, err := store.Recent("user-1", -1); len(got) != 0 || err != nil {
+		t.Errorf("Recent(-1) = %v, %v, want empty, nil", got, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	invalid := &SQLHistoryStore{DB: db, Table: "history; DROP TABLE users"}
+	if err := invalid.Add("user-1", "h1"); !errors.Is(err, ErrInvalidTableName) {
+		t.Errorf("Add() with an invalid table error = %v, want ErrInvalidTableName", err)
+	}
+	if _, err := invalid.Recent("user-1", 1); !errors.Is(err, ErrInvalidTableName) {
+		t.Errorf("Recent() with an invalid table error = %v, want ErrInvalidTableName", err)
+	}
+	if schema := invalid.Schema(); schema != "" {
+		t.Errorf("Schema() with an invalid table = %q, want empty", schema)
+	}
+}
+
+func TestNewSQLHistoryStore_TableName(t *testing.T) {
+	store, err := NewSQLHistoryStore(nil, "")
+	if err != nil || store.Table != "password_history" {
+		t.Errorf("NewSQLHistoryStore(\"\") = %v, %v, want table password_history", store, err)
+	}
+	if _, err := NewSQLHistoryStore(nil, "history; DROP TABLE users"); !errors.Is(err, ErrInvalidTableName) {
+		t.Errorf("NewSQLHistoryStore(injection) error = %v, want ErrInvalidTableName", err)
+	}
+}
@@ -0,0 +1,38 @@
+// Command bloomgen compiles a wordlist, one password per line, into a
+// normalized Bloom filter file for rule.BlocklistRule.
+//
+//	go run ./internal/bloomgen <wordlist> <output> [false positive rate]
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/wissensalt/paswot/rule"
+)
+
+func main() {
+	if len(os.Args) < 3 {
+		fmt.Fprintln(os.Stderr, "usage: bloomgen <wordlist> <output> [false positive rate]")
+		os.Exit(2)
+	}
+
+	builder := rule.NewBloomFilterBuilder()
+	if len(os.Args) > 3 {
+		rate, err := strconv.ParseFloat(os.Args[3], 64)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		builder.WithFalsePositiveRate(rate)
+	}
+	if err := builder.AddWordlistFile(os.Args[1]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := builder.Build().WriteFile(os.Args[2]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,145 @@
+package rule
+
+import (
+	"sync"
+)
+
+// KeyboardLayout describes a keyboard as rows of keys. Each key is written as
+// its unshifted character followed by its shifted character. Offsets[i] is
+// the horizontal position of the first key of row i, in keys; a key touches
+// the two keys above it at the same and next position, and the two keys below
+// it at the same and previous position.
+type KeyboardLayout struct {
+	Name    string
+	Rows    []string
+	Offsets []int
+}
+
+var QWERTY = &KeyboardLayout{
+	Name: "qwerty",
+	Rows: []string{
+		"`~1!2@3#4$5%6^7&8*9(0)-_=+",
+		"qQwWeErRtTyYuUiIoOpP[{]}\\|",
+		"aAsSdDfFgGhHjJkKlL;:'\"",
+		"zZxXcCvVbBnNmM,<.>/?",
+	},
+	Offsets: []int{0, 1, 1, 1},
+}
+
+// AZERTY is the French layout, including the ISO key left of "w".
+var AZERTY = &KeyboardLayout{
+	Name: "azerty",
+	Rows: []string{
+		"&1é2\"3'4(5-6è7_8ç9à0)°=+",
+		"aAzZeErRtTyYuUiIoOpP^¨$£",
+		"qQsSdDfFgGhHjJkKlLmMù%*µ",
+		"<>wWxXcCvVbBnN,?;.:/!§",
+	},
+	Offsets: []int{1, 1, 1, 0},
+}
+
+// QWERTZ is the German layout, including the ISO key left of "y".
+var QWERTZ = &KeyboardLayout{
+	Name: "qwertz",
+	Rows: []string{
+		"^°1!2\"3§4$5%6&7/8(9)0=ß?´`",
+		"qQwWeErRtTzZuUiIoOpPüÜ+*",
+		"aAsSdDfFgGhHjJkKlLöÖäÄ#'",
+		"<>yYxXcCvVbBnNmM,;.:-_",
+	},
+	Offsets: []int{0, 1, 1, 0},
+}
+
+// KeyboardLayouts are the layouts known to the strength estimator and the
+// default layouts of KeyboardRule.
+var KeyboardLayouts = []*KeyboardLayout{QWERTY, AZERTY, QWERTZ}
+
+var keyboardGraphs = sync.OnceValue(func() []*keyboardGraph {
+	graphs := make([]*keyboardGraph, len(KeyboardLayouts))
+	for i, layout := range KeyboardLayouts {
+		graphs[i] = newKeyboardGraph(layout)
+	}
+	return graphs
+})
+
+// keyboardGraphFor reuses the graph of a built-in layout.
+func keyboardGraphFor(layout *KeyboardLayout) *keyboardGraph {
+	for _, graph := range keyboardGraphs() {
+		if graph.layout == layout {
+			return graph
+		}
+	}
+	return newKeyboardGraph(layout)
+}
+
+type keyPosition struct {
+	row, x  int
+	shifted bool
+}
+
+// keyboardGraph is the adjacency model of a KeyboardLayout.
+type keyboardGraph struct {
+	layout    *KeyboardLayout
+	keys      map[rune]keyPosition
+	positions map[[2]int]bool
+}
+
+func newKeyboardGraph(layout *KeyboardLayout) *keyboardGraph {
+	g := &keyboardGraph{layout: layout, keys: map[rune]keyPosition{}, positions: map[[2]int]bool{}}
+	for row, keys := range layout.Rows {
+		runes := []rune(keys)
+		for i := 0; i+1 < len(runes); i += 2 {
+			x := i/2 + layout.Offsets[row]
+			g.keys[runes[i]] = keyPosition{row: row, x: x}
+			g.keys[runes[i+1]] = keyPosition{row: row, x: x, shifted: true}
+			g.positions[[2]int{row, x}] = true
+		}
+	}
+	return g
+}
+
+var keyboardDirections = [][2]int{{0, -1}, {0, 1}, {-1, 0}, {-1, 1}, {1, -1}, {1, 0}}
+
+// direction returns the index in keyboardDirections of the step from a to b,
+// or -1 when the keys do not touch.
+func (g *keyboardGraph) direction(a, b rune) int {
+	from, ok := g.keys[a]
+	if !ok {
+		return -1
+	}
+	to, ok := g.keys[b]
+	if !ok {
+		return -1
+	}
+	step := [2]int{to.row - from.row, to.x - from.x}
+	for i, d := range keyboardDirections {
+		if d == step {
+			return i
+		}
+	}
+	return -1
+}
+
+func (g *keyboardGraph) adjacent(a, b rune) bool {
+	return g.direction(a, b) >= 0
+}
+
+func (g *keyboardGraph) shifted(r rune) bool {
+	return g.keys[r].shifted
+}
+
+func (g *keyboardGraph) startingPositions() int {
+	return len(g.positions)
+}
+
+func (g *keyboardGraph) averageDegree() float64 {
+	neighbours := 0
+	for position := range g.positions {
+		for _, d := range keyboardDirections {
+			if g.positions[[2]int{position[0] + d[0], position[1] + d[1]}] {
+				neighbours++
+			}
+		}
+	}
+	return float64(neighbours) / float64(len(g.positions))
+}
@@ -0,0 +1,70 @@
+package rule
+
+// KeyboardRule rejects passwords containing a run of more than MaxLength
+// adjacent keys, such as "qwerty" or "zaq1", on any of Layouts. Shifted
+// characters count as their key, so "QWE" and "!@#" are runs too.
+type KeyboardRule struct {
+	MaxLength int
+	Layouts   []*KeyboardLayout
+}
+
+func NewKeyboardRule(maxLength int) *KeyboardRule {
+	return &KeyboardRule{MaxLength: maxLength, Layouts: KeyboardLayouts}
+}
+
+type KeyboardRuleBuilder struct {
+	KeyboardRule *KeyboardRule
+}
+
+func NewKeyboardRuleBuilder() *KeyboardRuleBuilder {
+	return &KeyboardRuleBuilder{KeyboardRule: NewKeyboardRule(3)}
+}
+
+func (builder *KeyboardRuleBuilder) WithMaxLength(maxLength int) *KeyboardRuleBuilder {
+	builder.KeyboardRule.MaxLength = maxLength
+	return builder
+}
+
+func (builder *KeyboardRuleBuilder) WithLayouts(layouts ...*KeyboardLayout) *KeyboardRuleBuilder {
+	builder.KeyboardRule.Layouts = layouts
+	return builder
+}
+
+func (builder *KeyboardRuleBuilder) Build() *KeyboardRule {
+	return builder.KeyboardRule
+}
+
+func (r *KeyboardRule) Name() string {
+	return "keyboard"
+}
+
+func (r *KeyboardRule) Validate(password string) (bool, error) {
+	if violations := r.Violations(password); len(violations) > 0 {
+		return false, &violations[0]
+	}
+
+	return true, nil
+}
+
+func (r *KeyboardRule) Violations(password string) []Violation {
+	runes := []rune(password)
+	for _, layout := range r.Layouts {
+		graph := keyboardGraphFor(layout)
+		run := []rune(longestRun(runes, func(previous, char rune) int {
+			if graph.adjacent(previous, char) {
+				return 1
+			}
+			return 0
+		}))
+
+		if len(run) > r.MaxLength {
+			return []Violation{newViolation(r.Name(), CodeKeyboardSequence, r.MaxLength, len(run), map[string]any{
+				"max":      r.MaxLength,
+				"sequence": string(run),
+				"layout":   layout.Name,
+			})}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package rule
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestKeyboardRuleBuilder(t *testing.T) {
+	r := NewKeyboardRuleBuilder().WithMaxLength(3).WithLayouts(AZERTY).Build()
+	if r.MaxLength != 3 || len(r.Layouts) != 1 || r.Layouts[0] != AZERTY {
+		t.Errorf("KeyboardRule = %+v, want MaxLength 3 on AZERTY", r)
+	}
+
+	if r := NewKeyboardRuleBuilder().Build(); !reflect.DeepEqual(r, NewKeyboardRule(3)) {
+		t.Errorf("NewKeyboardRuleBuilder().Build() = %+v, want NewKeyboardRule(3)", r)
+	}
+	if valid, err := NewKeyboardRuleBuilder().Build().Validate("Tr0ub4dor&3"); !valid || err != nil {
+		t.Errorf("default KeyboardRule Validate() = %v, %v, want true, nil", valid, err)
+	}
+}
+
+func TestKeyboardRule_Validate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		layout   *KeyboardLayout
+		password string
+		sequence string
+	}{
+		{name: "QWERTY row", layout: QWERTY, password: "Xqwerty9!", sequence: "qwerty"},
+		{name: "QWERTY shifted", layout: QWERTY, password: "ab!@#$", sequence: "!@#$"},
+		{name: "QWERTY diagonal", layout: QWERTY, password: "1qaz2wsx", sequence: "1qaz"},
+		{name: "AZERTY row", layout: AZERTY, password: "Xazerty9!", sequence: "azerty"},
+		{name: "QWERTZ row", layout: QWERTZ, password: "Xqwertz9!", sequence: "qwertz"},
+		{name: "QWERTZ bottom row", layout: QWERTZ, password: "9yxcvb", sequence: "yxcvb"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewKeyboardRuleBuilder().WithMaxLength(3).WithLayouts(tc.layout).Build()
+			valid, err := r.Validate(tc.password)
+			if valid || !errors.Is(err, ErrKeyboardSequence) {
+				t.Fatalf("Validate(%q) = %v, %v, want false, ErrKeyboardSequence", tc.password, valid, err)
+			}
+			var violation *Violation
+			if errors.As(err, &violation) && violation.Params["sequence"] != tc.sequence {
+				t.Errorf("sequence = %v, want %q", violation.Params["sequence"], tc.sequence)
+			}
+		})
+	}
+}
+
+func TestKeyboardRule_LayoutSpecific(t *testing.T) {
+	// "azerty" contains only "erty" as a QWERTY run.
+	qwerty := NewKeyboardRuleBuilder().WithMaxLength(4).WithLayouts(QWERTY).Build()
+	if valid, err := qwerty.Validate("azerty"); !valid || err != nil {
+		t.Errorf("QWERTY Validate(%q) = %v, %v, want true, nil", "azerty", valid, err)
+	}
+	if valid, _ := NewKeyboardRule(4).Validate("azerty"); valid {
+		t.Error("default layouts should include AZERTY")
+	}
+}
+
+func TestPaswotRule_WithRepeatSequenceKeyboard(t *testing.T) {
+	paswotRule := NewPaswotRuleBuilder().
+		WithLength(NewLengthRule(8, 16)).
+		WithRepeat(NewRepeatRule(2)).
+		WithSequence(NewSequenceRule(3)).
+		WithKeyboard(NewKeyboardRule(3)).
+		Build()
+
+	report := paswotRule.Report("aaaabcde!qwer")
+	want := []Code{CodeTooManyRepeats, CodeContainsSequence, CodeKeyboardSequence}
+	if codes := report.Codes(); len(codes) != len(want) || codes[0] != want[0] || codes[1] != want[1] || codes[2] != want[2] {
+		t.Errorf("Report codes = %v, want %v", codes, want)
+	}
+}
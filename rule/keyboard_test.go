@@ -0,0 +1,59 @@
+package rule
+
+import "testing"
+
+func TestKeyboardGraph_Adjacent(t *testing.T) {
+	g := newKeyboardGraph(QWERTY)
+	testCases := []struct {
+		a, b rune
+		want bool
+	}{
+		{a: 'q', b: 'w', want: true},
+		{a: 'q', b: '1', want: true},
+		{a: 'q', b: '2', want: true},
+		{a: 'q', b: 'a', want: true},
+		{a: 'w', b: 'a', want: true},
+		{a: 'Q', b: '@', want: true},
+		{a: 'q', b: 'e', want: false},
+		{a: 'q', b: 's', want: false},
+		{a: 'q', b: 'é', want: false},
+	}
+
+	for _, tc := range testCases {
+		if got := g.adjacent(tc.a, tc.b); got != tc.want {
+			t.Errorf("adjacent(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestKeyboardGraph_Degree(t *testing.T) {
+	g := newKeyboardGraph(QWERTY)
+	if got := g.startingPositions(); got != 47 {
+		t.Errorf("startingPositions() = %d, want 47", got)
+	}
+	if got := g.averageDegree(); got < 4 || got > 6 {
+		t.Errorf("averageDegree() = %f, want between 4 and 6", got)
+	}
+}
+
+func TestKeyboardGraph_Layouts(t *testing.T) {
+	testCases := []struct {
+		layout *KeyboardLayout
+		a, b   rune
+		want   bool
+	}{
+		{layout: AZERTY, a: 'a', b: 'z', want: true},
+		{layout: AZERTY, a: 'q', b: 'w', want: true},
+		{layout: AZERTY, a: 'w', b: '<', want: true},
+		{layout: AZERTY, a: 'é', b: 'z', want: true},
+		{layout: QWERTZ, a: 't', b: 'z', want: true},
+		{layout: QWERTZ, a: 'a', b: 'y', want: true},
+		{layout: QWERTZ, a: 'z', b: 'y', want: false},
+	}
+
+	for _, tc := range testCases {
+		if got := newKeyboardGraph(tc.layout).adjacent(tc.a, tc.b); got != tc.want {
+			t.Errorf("%s adjacent(%q, %q) = %v, want %v", tc.layout.Name, tc.a, tc.b, got, tc.want)
+		}
+	}
+}
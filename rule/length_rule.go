@@ -1,13 +1,13 @@
 package rule
 
 import (
-	"errors"
 	"fmt"
 )
 
 type LengthRule struct {
-	Min int
-	Max int
+	Min  int
+	Max  int
+	Unit LengthUnit
 }
 
 func NewLengthRule(min, max int) *LengthRule {
@@ -32,18 +32,44 @@ func (builder *LengthRuleBuilder) WithMax(max int) *LengthRuleBuilder {
 	return builder
 }
 
+func (builder *LengthRuleBuilder) WithUnit(unit LengthUnit) *LengthRuleBuilder {
+	builder.LengthRule.Unit = unit
+	return builder
+}
+
 func (builder *LengthRuleBuilder) Build() *LengthRule {
 	return builder.LengthRule
 }
 
+func (l *LengthRule) Name() string {
+	return "length"
+}
+
+func (l *LengthRule) GenerationHint() GenerationHint {
+	return GenerationHint{MinLength: l.Min}
+}
+
 func (l *LengthRule) ToString() string {
 	return fmt.Sprintf("Min: %d, Max: %d", l.Min, l.Max)
 }
 
 func (l *LengthRule) Validate(password string) (bool, error) {
-	if len(password) < l.Min || len(password) > l.Max {
-		return false, errors.New("password length must be between " + fmt.Sprintf("%d", l.Min) + " and " + fmt.Sprintf("%d", l.Max))
+	if violations := l.Violations(password); len(violations) > 0 {
+		return false, &violations[0]
 	}
 
 	return true, nil
 }
+
+func (l *LengthRule) Violations(password string) []Violation {
+	params := map[string]any{"min": l.Min, "max": l.Max}
+	length := l.Unit.Count(password)
+	if length < l.Min {
+		return []Violation{newViolation(l.Name(), CodeTooShort, l.Min, length, params)}
+	}
+	if length > l.Max {
+		return []Violation{newViolation(l.Name(), CodeTooLong, l.Max, length, params)}
+	}
+
+	return nil
+}
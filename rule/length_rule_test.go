@@ -93,3 +93,20 @@ func TestLengthRule_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestLengthRule_Unit(t *testing.T) {
+	password := "pässwört"
+	if _, err := NewLengthRule(9, 16).Validate(password); err != nil {
+		t.Errorf("Validate() in bytes error = %v, want nil", err)
+	}
+
+	rule := NewLengthRuleBuilder().WithMin(9).WithMax(16).WithUnit(LengthCodePoints).Build()
+	if _, err := rule.Validate(password); err == nil {
+		t.Error("Validate() in code points should reject 8 characters")
+	}
+
+	rule = NewLengthRuleBuilder().WithMin(1).WithMax(2).WithUnit(LengthGraphemes).Build()
+	if _, err := rule.Validate("🇮🇩👍🏽"); err != nil {
+		t.Errorf("Validate() in graphemes error = %v, want nil", err)
+	}
+}
@@ -1,20 +1,35 @@
 package rule
 
 import (
-	"fmt"
 	"strings"
 )
 
 type NoWhitespaceRule struct{}
 
+func (r NoWhitespaceRule) Name() string {
+	return "no_whitespace"
+}
+
+func (r NoWhitespaceRule) GenerationHint() GenerationHint {
+	return GenerationHint{Excluded: " "}
+}
+
 func (r NoWhitespaceRule) Validate(password string) (bool, error) {
-	if strings.Contains(password, " ") {
-		return false, fmt.Errorf("password cannot contain whitespace")
+	if violations := r.Violations(password); len(violations) > 0 {
+		return false, &violations[0]
 	}
 
 	return true, nil
 }
 
+func (r NoWhitespaceRule) Violations(password string) []Violation {
+	if count := strings.Count(password, " "); count > 0 {
+		return []Violation{newViolation(r.Name(), CodeContainsWhitespace, 0, count, nil)}
+	}
+
+	return nil
+}
+
 func NewNoWhitespaceRule() *NoWhitespaceRule {
 	return &NoWhitespaceRule{}
 }
@@ -0,0 +1,244 @@
+package rule
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// The EFF large wordlist, https://www.eff.org/dice, published by the
+// Electronic Frontier Foundation under CC BY 3.0 US.
+//
+//go:embed data/eff_large_wordlist.txt
+var effLargeWordlist string
+
+// EFFLargeWordlist returns the 7776 words of the EFF large wordlist, 12.9
+// bits of entropy per word. Four of them contain a hyphen, such as "t-shirt".
+// The slice is shared and must not be modified.
+var EFFLargeWordlist = sync.OnceValue(func() []string {
+	words, _ := LoadWordlist(strings.NewReader(effLargeWordlist))
+	return words
+})
+
+// LoadWordlist reads one word per line, skipping blank lines and duplicates.
+// Diceware lists with a roll number before each word, such as
+// "11111	abacus", are accepted as well.
+func LoadWordlist(r io.Reader) ([]string, error) {
+	var words []string
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		word := fields[len(fields)-1]
+		if !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+	return words, scanner.Err()
+}
+
+func LoadWordlistFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return LoadWordlist(file)
+}
+
+// Capitalization selects which words of a generated passphrase start with an
+// uppercase letter.
+type Capitalization int
+
+const (
+	CapitalizeNone Capitalization = iota
+	CapitalizeEach
+	// CapitalizeRandom capitalizes each word with a probability of one half,
+	// adding a bit of entropy per word.
+	CapitalizeRandom
+)
+
+// PassphraseRule describes passphrases of Words words drawn from Wordlist,
+// the EFF large wordlist when empty, and joined by Separator. Digits and
+// Symbols are the number of random digits and symbols appended to random
+// words. Added to a PaswotRule it switches paswot.Generate to passphrases and
+// rejects passwords of fewer than Words words. Words are split at Separator,
+// or, without one, at every character that is not a letter and before every
+// uppercase letter, so "CorrectHorse7Battery" has three. Words of the list
+// that contain Separator, such as "t-shirt" with "-", count as one word.
+type PassphraseRule struct {
+	Words          int
+	Separator      string
+	Capitalization Capitalization
+	Digits         int
+	Symbols        int
+	Wordlist       []string
+}
+
+func NewPassphraseRule(words int) *PassphraseRule {
+	return &PassphraseRule{Words: words, Separator: "-"}
+}
+
+// List returns the words passphrases are drawn from, Wordlist without blank
+// and repeated words, so that each word adds log2 of the list length to the
+// entropy.
+func (r *PassphraseRule) List() []string {
+	if len(r.Wordlist) == 0 {
+		return EFFLargeWordlist()
+	}
+
+	var words []string
+	seen := map[string]bool{}
+	for _, word := range r.Wordlist {
+		if word != "" && !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+func (r *PassphraseRule) IsValid() (bool, error) {
+	if r.Words <= 0 {
+		return false, errors.New("passphrase rule needs at least one word")
+	}
+	if r.Digits < 0 || r.Symbols < 0 {
+		return false, errors.New("passphrase rule digits and symbols cannot be negative")
+	}
+	if r.Separator == "" && r.Capitalization != CapitalizeEach {
+		return false, errors.New("passphrase rule without a separator must capitalize each word")
+	}
+	return true, nil
+}
+
+func (r *PassphraseRule) Name() string {
+	return "passphrase"
+}
+
+func (r *PassphraseRule) Validate(password string) (bool, error) {
+	if violations := r.Violations(password); len(violations) > 0 {
+		return false, &violations[0]
+	}
+
+	return true, nil
+}
+
+func (r *PassphraseRule) Violations(password string) []Violation {
+	if count := r.CountWords(password); count < r.Words {
+		return []Violation{newViolation(r.Name(), CodeTooFewWords, r.Words, count, map[string]any{"min": r.Words})}
+	}
+
+	return nil
+}
+
+// CountWords returns the number of words in password.
+func (r *PassphraseRule) CountWords(password string) int {
+	return len(r.SplitWords(password))
+}
+
+// SplitWords returns the words of password as described for PassphraseRule.
+// With a Separator, the words keep the digits and symbols appended to them.
+func (r *PassphraseRule) SplitWords(password string) []string {
+	if r.Separator != "" {
+		return r.splitSeparated(password)
+	}
+
+	var words []string
+	var word strings.Builder
+	for _, char := range password {
+		if word.Len() > 0 && (unicode.IsUpper(char) || !unicode.IsLetter(char)) {
+			words = append(words, word.String())
+			word.Reset()
+		}
+		if unicode.IsLetter(char) {
+			word.WriteRune(char)
+		}
+	}
+	if word.Len() > 0 {
+		words = append(words, word.String())
+	}
+	return words
+}
+
+func (r *PassphraseRule) splitSeparated(password string) []string {
+	// Lowercase list words that contain the separator, by number of parts
+	compounds := map[string]int{}
+	longest := 1
+	for _, word := range r.List() {
+		if parts := strings.Count(word, r.Separator) + 1; parts > 1 {
+			compounds[strings.ToLower(word)] = parts
+			longest = max(longest, parts)
+		}
+	}
+
+	var words []string
+	parts := strings.Split(password, r.Separator)
+	for i := 0; i < len(parts); i++ {
+		if strings.IndexFunc(parts[i], unicode.IsLetter) < 0 {
+			continue
+		}
+		// Digits and symbols are only ever appended to the end of a word
+		n := min(longest, len(parts)-i)
+		for ; n > 1; n-- {
+			word := strings.Join(parts[i:i+n], r.Separator)
+			trimmed := strings.TrimRightFunc(word, func(char rune) bool { return !unicode.IsLetter(char) })
+			if compounds[strings.ToLower(trimmed)] == n {
+				break
+			}
+		}
+		words = append(words, strings.Join(parts[i:i+n], r.Separator))
+		i += n - 1
+	}
+	return words
+}
+
+type PassphraseRuleBuilder struct {
+	PassphraseRule *PassphraseRule
+}
+
+func NewPassphraseRuleBuilder() *PassphraseRuleBuilder {
+	return &PassphraseRuleBuilder{PassphraseRule: NewPassphraseRule(6)}
+}
+
+func (builder *PassphraseRuleBuilder) WithWords(words int) *PassphraseRuleBuilder {
+	builder.PassphraseRule.Words = words
+	return builder
+}
+
+func (builder *PassphraseRuleBuilder) WithSeparator(separator string) *PassphraseRuleBuilder {
+	builder.PassphraseRule.Separator = separator
+	return builder
+}
+
+func (builder *PassphraseRuleBuilder) WithCapitalization(capitalization Capitalization) *PassphraseRuleBuilder {
+	builder.PassphraseRule.Capitalization = capitalization
+	return builder
+}
+
+func (builder *PassphraseRuleBuilder) WithDigits(digits int) *PassphraseRuleBuilder {
+	builder.PassphraseRule.Digits = digits
+	return builder
+}
+
+func (builder *PassphraseRuleBuilder) WithSymbols(symbols int) *PassphraseRuleBuilder {
+	builder.PassphraseRule.Symbols = symbols
+	return builder
+}
+
+func (builder *PassphraseRuleBuilder) WithWordlist(wordlist []string) *PassphraseRuleBuilder {
+	builder.PassphraseRule.Wordlist = wordlist
+	return builder
+}
+
+func (builder *PassphraseRuleBuilder) Build() *PassphraseRule {
+	return builder.PassphraseRule
+}
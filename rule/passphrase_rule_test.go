@@ -0,0 +1,119 @@
+package rule
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEFFLargeWordlist(t *testing.T) {
+	words := EFFLargeWordlist()
+	if len(words) != 7776 {
+		t.Errorf("len(EFFLargeWordlist()) = %d, want 7776", len(words))
+	}
+	if words[0] != "abacus" || words[len(words)-1] != "zoom" {
+		t.Errorf("EFFLargeWordlist() = [%s ... %s], want [abacus ... zoom]", words[0], words[len(words)-1])
+	}
+}
+
+func TestLoadWordlist(t *testing.T) {
+	words, err := LoadWordlist(strings.NewReader("11111\tabacus\n\n11112 abdomen\nabide\r\nabide\n"))
+	if err != nil {
+		t.Fatalf("LoadWordlist() error = %v", err)
+	}
+	if got := strings.Join(words, ","); got != "abacus,abdomen,abide" {
+		t.Errorf("LoadWordlist() = %s, want abacus,abdomen,abide", got)
+	}
+}
+
+func TestPassphraseRuleBuilder(t *testing.T) {
+	r := NewPassphraseRuleBuilder().
+		WithWords(4).
+		WithSeparator(".").
+		WithCapitalization(CapitalizeRandom).
+		WithDigits(1).
+		WithSymbols(2).
+		WithWordlist([]string{"a", "b"}).
+		Build()
+
+	if r.Words != 4 || r.Separator != "." || r.Capitalization != CapitalizeRandom || r.Digits != 1 || r.Symbols != 2 {
+		t.Errorf("PassphraseRuleBuilder.Build() = %+v", r)
+	}
+	if len(r.List()) != 2 {
+		t.Errorf("List() = %v, want the custom wordlist", r.List())
+	}
+	if got := (&PassphraseRule{Wordlist: []string{"a", "b", "a", "", "b"}}).List(); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("List() = %q, want the custom wordlist without blank and repeated words", got)
+	}
+	if len(NewPassphraseRule(4).List()) != 7776 {
+		t.Error("List() should default to the EFF large wordlist")
+	}
+}
+
+func TestPassphraseRule_SplitWords(t *testing.T) {
+	r := NewPassphraseRule(4)
+	if got, want := r.SplitWords("Yo-yo-correct7-t-shirt"), []string{"Yo-yo", "correct7", "t-shirt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitWords() = %q, want %q", got, want)
+	}
+
+	r = &PassphraseRule{Words: 3, Capitalization: CapitalizeEach}
+	if got, want := r.SplitWords("CorrectHorse7Battery!"), []string{"Correct", "Horse", "Battery"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitWords() = %q, want %q", got, want)
+	}
+}
+
+func TestPassphraseRule_IsValid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rule    *PassphraseRule
+		wantErr bool
+	}{
+		{"Valid", NewPassphraseRule(4), false},
+		{"No words", NewPassphraseRule(0), true},
+		{"Negative digits", &PassphraseRule{Words: 4, Separator: "-", Digits: -1}, true},
+		{"No separator", &PassphraseRule{Words: 4}, true},
+		{"No separator, capitalized", &PassphraseRule{Words: 4, Capitalization: CapitalizeEach}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.rule.IsValid(); (err != nil) != tc.wantErr {
+				t.Errorf("IsValid() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestPassphraseRule_Validate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     *PassphraseRule
+		password string
+		want     int
+	}{
+		{"Separated", NewPassphraseRule(4), "correct-horse7-battery-staple!", 4},
+		{"Separator only", NewPassphraseRule(4), "correct--horse-7", 2},
+		{"Too few", NewPassphraseRule(4), "correct-horse-battery", 3},
+		{"Hyphenated words", NewPassphraseRule(4), "T-shirt-yo-yo7-drop-down-felt-tip!", 4},
+		{"Hyphenated prefix", NewPassphraseRule(4), "drop-shirt-t-yo", 4},
+		{"Capitalized", &PassphraseRule{Words: 3, Capitalization: CapitalizeEach}, "CorrectHorse7Battery", 3},
+		{"Lowercase run", &PassphraseRule{Words: 3, Capitalization: CapitalizeEach}, "correcthorse", 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.rule.CountWords(tc.password); got != tc.want {
+				t.Errorf("CountWords(%q) = %d, want %d", tc.password, got, tc.want)
+			}
+
+			_, err := tc.rule.Validate(tc.password)
+			if wantErr := tc.want < tc.rule.Words; (err != nil) != wantErr {
+				t.Errorf("Validate(%q) error = %v, wantErr %v", tc.password, err, wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrTooFewWords) {
+				t.Errorf("Validate() error = %v, want ErrTooFewWords", err)
+			}
+		})
+	}
+}
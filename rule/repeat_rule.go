@@ -0,0 +1,59 @@
+package rule
+
+// RepeatRule rejects passwords with more than MaxConsecutive identical
+// characters in a row, e.g. "aaa" when MaxConsecutive is 2.
+type RepeatRule struct {
+	MaxConsecutive int
+}
+
+func NewRepeatRule(maxConsecutive int) *RepeatRule {
+	return &RepeatRule{MaxConsecutive: maxConsecutive}
+}
+
+type RepeatRuleBuilder struct {
+	RepeatRule *RepeatRule
+}
+
+func NewRepeatRuleBuilder() *RepeatRuleBuilder {
+	return &RepeatRuleBuilder{RepeatRule: NewRepeatRule(2)}
+}
+
+func (builder *RepeatRuleBuilder) WithMaxConsecutive(maxConsecutive int) *RepeatRuleBuilder {
+	builder.RepeatRule.MaxConsecutive = maxConsecutive
+	return builder
+}
+
+func (builder *RepeatRuleBuilder) Build() *RepeatRule {
+	return builder.RepeatRule
+}
+
+func (r *RepeatRule) Name() string {
+	return "repeat"
+}
+
+func (r *RepeatRule) Validate(password string) (bool, error) {
+	if violations := r.Violations(password); len(violations) > 0 {
+		return false, &violations[0]
+	}
+
+	return true, nil
+}
+
+func (r *RepeatRule) Violations(password string) []Violation {
+	runes := []rune(password)
+	longest, start := 0, 0
+	for i := range runes {
+		if runes[i] != runes[start] {
+			start = i
+		}
+		if run := i - start + 1; run > longest {
+			longest = run
+		}
+	}
+
+	if longest > r.MaxConsecutive {
+		return []Violation{newViolation(r.Name(), CodeTooManyRepeats, r.MaxConsecutive, longest, map[string]any{"max": r.MaxConsecutive})}
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package rule
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRepeatRuleBuilder(t *testing.T) {
+	r := NewRepeatRuleBuilder().WithMaxConsecutive(2).Build()
+	if r.MaxConsecutive != 2 {
+		t.Errorf("MaxConsecutive = %d, want 2", r.MaxConsecutive)
+	}
+
+	if r := NewRepeatRuleBuilder().Build(); !reflect.DeepEqual(r, NewRepeatRule(2)) {
+		t.Errorf("NewRepeatRuleBuilder().Build() = %+v, want NewRepeatRule(2)", r)
+	}
+	if valid, err := NewRepeatRuleBuilder().Build().Validate("Tr0ub4dor&3"); !valid || err != nil {
+		t.Errorf("default RepeatRule Validate() = %v, %v, want true, nil", valid, err)
+	}
+}
+
+func TestRepeatRule_Validate(t *testing.T) {
+	r := NewRepeatRule(2)
+	testCases := []struct {
+		password string
+		longest  int
+	}{
+		{password: "Aaaaaaa1!", longest: 6},
+		{password: "ab111cd", longest: 3},
+		{password: "pass!!!", longest: 3},
+	}
+
+	for _, tc := range testCases {
+		valid, err := r.Validate(tc.password)
+		if valid || !errors.Is(err, ErrTooManyRepeats) {
+			t.Errorf("Validate(%q) = %v, %v, want false, ErrTooManyRepeats", tc.password, valid, err)
+			continue
+		}
+		var violation *Violation
+		if errors.As(err, &violation) && violation.Actual != tc.longest {
+			t.Errorf("Validate(%q) actual = %v, want %d", tc.password, violation.Actual, tc.longest)
+		}
+	}
+
+	for _, password := range []string{"", "aabbcc", "Aa1!Aa1!"} {
+		if valid, err := r.Validate(password); !valid || err != nil {
+			t.Errorf("Validate(%q) = %v, %v, want true, nil", password, valid, err)
+		}
+	}
+}
@@ -2,12 +2,91 @@ package rule
 
 import (
 	"errors"
+	"strings"
 )
 
+// Rule is a single password requirement. Rules that can report several
+// violations at once also implement Reporter, and rules that constrain how
+// passwords are generated implement Hinter.
+type Rule interface {
+	Name() string
+	Validate(password string) (bool, error)
+}
+
+type Reporter interface {
+	Violations(password string) []Violation
+}
+
+type Hinter interface {
+	GenerationHint() GenerationHint
+}
+
+type CharsetRequirement struct {
+	Charset Charset
+	Min     int
+}
+
+// GenerationHint tells the generator how to build candidates that a rule will
+// accept: the minimum length, characters that must be included and characters
+// that must never be used.
+type GenerationHint struct {
+	MinLength int
+	Required  []CharsetRequirement
+	Excluded  string
+}
+
+// PaswotRule is a password policy. When NFKC is set, passwords are NFKC
+// normalized before they are validated; hash them with the same option, see
+// paswot.HashOptions.
 type PaswotRule struct {
 	Length       *LengthRule
 	Character    *CharacterRule
 	NoWhitespace *NoWhitespaceRule
+	Rules        []Rule
+	NFKC         bool
+}
+
+// Normalize returns password as the rules see it.
+func (p *PaswotRule) Normalize(password string) string {
+	if p.NFKC {
+		return NormalizeNFKC(password)
+	}
+	return password
+}
+
+// All returns the configured rules in validation order: the built-in
+// NoWhitespace, Length and Character rules followed by custom rules in the
+// order they were added.
+func (p *PaswotRule) All() []Rule {
+	var rules []Rule
+	if p.NoWhitespace != nil {
+		rules = append(rules, p.NoWhitespace)
+	}
+	if p.Length != nil {
+		rules = append(rules, p.Length)
+	}
+	if p.Character != nil {
+		rules = append(rules, p.Character)
+	}
+	return append(rules, p.Rules...)
+}
+
+// GenerationHint merges the hints of all rules.
+func (p *PaswotRule) GenerationHint() GenerationHint {
+	var hint GenerationHint
+	var excluded strings.Builder
+	for _, r := range p.All() {
+		hinter, ok := r.(Hinter)
+		if !ok {
+			continue
+		}
+		h := hinter.GenerationHint()
+		hint.MinLength = max(hint.MinLength, h.MinLength)
+		hint.Required = append(hint.Required, h.Required...)
+		excluded.WriteString(h.Excluded)
+	}
+	hint.Excluded = excluded.String()
+	return hint
 }
 
 func (p *PaswotRule) IsValid() (bool, error) {
@@ -15,7 +94,7 @@ func (p *PaswotRule) IsValid() (bool, error) {
 	if p.Length != nil && p.Length.Min > p.Length.Max {
 		return false, errors.New("length rule min violates max rule")
 	}
-	
+
 	if p.Character != nil {
 		if p.Length != nil {
 			// Character rule violates min length rule
@@ -33,11 +112,41 @@ func (p *PaswotRule) IsValid() (bool, error) {
 		if p.NoWhitespace != nil && p.Character.Sum() == 0 {
 			return false, errors.New("no whitespace rule violates the character rule")
 		}
+
+		// Character rule excludes every character of a required class
+		for _, requirement := range p.Character.GenerationHint().Required {
+			if requirement.Charset == "" {
+				return false, errors.New("character rule excludes every character of a required class")
+			}
+		}
 	}
 
 	return true, nil
 }
 
+// Report checks password against every configured rule and collects all
+// violations instead of stopping at the first one.
+func (p *PaswotRule) Report(password string) *ValidationReport {
+	return p.ReportWithContext(password, nil)
+}
+
+// ReportWithContext is Report for rules that depend on the account the
+// password belongs to, see ContextRule.
+func (p *PaswotRule) ReportWithContext(password string, ctx *ValidationContext) *ValidationReport {
+	report := &ValidationReport{}
+	password = p.Normalize(password)
+	if password == "" {
+		report.Violations = append(report.Violations, *EmptyViolation())
+		return report
+	}
+
+	for _, r := range p.All() {
+		report.Violations = append(report.Violations, violationsOf(r, password, ctx)...)
+	}
+
+	return report
+}
+
 type PaswotRuleBuilder struct {
 	PaswotRule *PaswotRule
 }
@@ -61,6 +170,61 @@ func (builder *PaswotRuleBuilder) WithNoWhitespace(noWhitespace *NoWhitespaceRul
 	return builder
 }
 
+func (builder *PaswotRuleBuilder) WithMinEntropy(minEntropy *MinEntropyRule) *PaswotRuleBuilder {
+	return builder.WithRule(minEntropy)
+}
+
+func (builder *PaswotRuleBuilder) WithMinStrength(minStrength *MinStrengthRule) *PaswotRuleBuilder {
+	return builder.WithRule(minStrength)
+}
+
+func (builder *PaswotRuleBuilder) WithBreached(breached *BreachedRule) *PaswotRuleBuilder {
+	return builder.WithRule(breached)
+}
+
+func (builder *PaswotRuleBuilder) WithBlocklist(blocklist *BlocklistRule) *PaswotRuleBuilder {
+	return builder.WithRule(blocklist)
+}
+
+func (builder *PaswotRuleBuilder) WithUserAttribute(userAttribute *UserAttributeRule) *PaswotRuleBuilder {
+	return builder.WithRule(userAttribute)
+}
+
+func (builder *PaswotRuleBuilder) WithHistory(history *HistoryRule) *PaswotRuleBuilder {
+	return builder.WithRule(history)
+}
+
+func (builder *PaswotRuleBuilder) WithRepeat(repeat *RepeatRule) *PaswotRuleBuilder {
+	return builder.WithRule(repeat)
+}
+
+func (builder *PaswotRuleBuilder) WithSequence(sequence *SequenceRule) *PaswotRuleBuilder {
+	return builder.WithRule(sequence)
+}
+
+func (builder *PaswotRuleBuilder) WithKeyboard(keyboard *KeyboardRule) *PaswotRuleBuilder {
+	return builder.WithRule(keyboard)
+}
+
+func (builder *PaswotRuleBuilder) WithAllowedChars(allowedChars *AllowedCharsRule) *PaswotRuleBuilder {
+	return builder.WithRule(allowedChars)
+}
+
+// WithPassphrase makes Generate produce passphrases, see PassphraseRule.
+func (builder *PaswotRuleBuilder) WithPassphrase(passphrase *PassphraseRule) *PaswotRuleBuilder {
+	return builder.WithRule(passphrase)
+}
+
+func (builder *PaswotRuleBuilder) WithNFKC(nfkc bool) *PaswotRuleBuilder {
+	builder.PaswotRule.NFKC = nfkc
+	return builder
+}
+
+func (builder *PaswotRuleBuilder) WithRule(rule Rule) *PaswotRuleBuilder {
+	builder.PaswotRule.Rules = append(builder.PaswotRule.Rules, rule)
+	return builder
+}
+
 func (builder *PaswotRuleBuilder) Build() *PaswotRule {
 	return builder.PaswotRule
 }
@@ -80,3 +244,21 @@ func DefaultRule() *PaswotRule {
 		WithNoWhitespace(NewNoWhitespaceRule()).
 		Build()
 }
+
+func violationsOf(r Rule, password string, ctx *ValidationContext) []Violation {
+	if _, ok := r.(ContextRule); !ok {
+		if reporter, ok := r.(Reporter); ok {
+			return reporter.Violations(password)
+		}
+	}
+
+	_, err := ValidateRule(r, password, ctx)
+	if err == nil {
+		return nil
+	}
+	var violation *Violation
+	if errors.As(err, &violation) {
+		return []Violation{*violation}
+	}
+	return []Violation{{Rule: r.Name(), Code: CodeRuleFailed, Message: err.Error()}}
+}
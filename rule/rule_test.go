@@ -1,6 +1,9 @@
 package rule
 
 import (
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -130,6 +133,18 @@ func TestPaswotRule_IsValid(t *testing.T) {
 				Build(),
 			wantErr: false,
 		},
+		{
+			name: "Invalid required class fully excluded",
+			rule: NewPaswotRuleBuilder().
+				WithCharacter(NewCharacterRuleBuilder().
+					WithMinNumber(1).
+					WithExcluded("23456789").
+					WithExcludeAmbiguous(true).
+					Build()).
+				Build(),
+			wantErr: true,
+			errText: "character rule excludes every character of a required class",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -145,3 +160,78 @@ func TestPaswotRule_IsValid(t *testing.T) {
 		})
 	}
 }
+
+type noCharRule struct {
+	char string
+}
+
+func (r noCharRule) Name() string {
+	return "no_" + r.char
+}
+
+func (r noCharRule) Validate(password string) (bool, error) {
+	if strings.Contains(password, r.char) {
+		return false, errors.New("password cannot contain " + r.char)
+	}
+	return true, nil
+}
+
+func (r noCharRule) GenerationHint() GenerationHint {
+	return GenerationHint{Excluded: r.char}
+}
+
+func TestPaswotRuleBuilder_WithRule(t *testing.T) {
+	first := noCharRule{char: "a"}
+	second := noCharRule{char: "b"}
+	paswotRule := NewPaswotRuleBuilder().
+		WithLength(NewLengthRule(8, 16)).
+		WithRule(first).
+		WithRule(second).
+		WithNoWhitespace(NewNoWhitespaceRule()).
+		Build()
+
+	var names []string
+	for _, r := range paswotRule.All() {
+		names = append(names, r.Name())
+	}
+	want := []string{"no_whitespace", "length", "no_a", "no_b"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("All() names = %v, want %v", names, want)
+	}
+}
+
+func TestPaswotRule_GenerationHint(t *testing.T) {
+	paswotRule := NewPaswotRuleBuilder().
+		WithLength(NewLengthRule(12, 16)).
+		WithCharacter(NewCharacterRule(1, 0, 2, 0)).
+		WithNoWhitespace(NewNoWhitespaceRule()).
+		WithRule(noCharRule{char: "0"}).
+		Build()
+
+	hint := paswotRule.GenerationHint()
+	if hint.MinLength != 12 {
+		t.Errorf("MinLength = %d, want 12", hint.MinLength)
+	}
+	wantRequired := []CharsetRequirement{{Charset: AlphabetUpperCase, Min: 1}, {Charset: Number, Min: 2}}
+	if !reflect.DeepEqual(hint.Required, wantRequired) {
+		t.Errorf("Required = %v, want %v", hint.Required, wantRequired)
+	}
+	if hint.Excluded != " 0" {
+		t.Errorf("Excluded = %q, want %q", hint.Excluded, " 0")
+	}
+}
+
+func TestPaswotRule_ReportCustomRule(t *testing.T) {
+	paswotRule := NewPaswotRuleBuilder().
+		WithLength(NewLengthRule(8, 16)).
+		WithRule(noCharRule{char: "x"}).
+		Build()
+
+	report := paswotRule.Report("xyz")
+	if !reflect.DeepEqual(report.Codes(), []Code{CodeTooShort, CodeRuleFailed}) {
+		t.Fatalf("Report() codes = %v, want %v", report.Codes(), []Code{CodeTooShort, CodeRuleFailed})
+	}
+	if v := report.Violations[1]; v.Rule != "no_x" || v.Message != "password cannot contain x" {
+		t.Errorf("Custom violation = %+v, want rule no_x with the rule's message", v)
+	}
+}
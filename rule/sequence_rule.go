@@ -0,0 +1,119 @@
+package rule
+
+import (
+	"unicode"
+)
+
+// SequenceRule rejects passwords containing an ascending or descending run of
+// consecutive letters ("abcd", "DCBA") or digits ("1234", "9876") longer than
+// MaxLength. Letters are compared ignoring case.
+type SequenceRule struct {
+	MaxLength  int
+	Alphabetic bool
+	Numeric    bool
+}
+
+func NewSequenceRule(maxLength int) *SequenceRule {
+	return &SequenceRule{MaxLength: maxLength, Alphabetic: true, Numeric: true}
+}
+
+type SequenceRuleBuilder struct {
+	SequenceRule *SequenceRule
+}
+
+func NewSequenceRuleBuilder() *SequenceRuleBuilder {
+	return &SequenceRuleBuilder{SequenceRule: NewSequenceRule(3)}
+}
+
+func (builder *SequenceRuleBuilder) WithMaxLength(maxLength int) *SequenceRuleBuilder {
+	builder.SequenceRule.MaxLength = maxLength
+	return builder
+}
+
+func (builder *SequenceRuleBuilder) WithAlphabetic(alphabetic bool) *SequenceRuleBuilder {
+	builder.SequenceRule.Alphabetic = alphabetic
+	return builder
+}
+
+func (builder *SequenceRuleBuilder) WithNumeric(numeric bool) *SequenceRuleBuilder {
+	builder.SequenceRule.Numeric = numeric
+	return builder
+}
+
+func (builder *SequenceRuleBuilder) Build() *SequenceRule {
+	return builder.SequenceRule
+}
+
+func (r *SequenceRule) Name() string {
+	return "sequence"
+}
+
+func (r *SequenceRule) Validate(password string) (bool, error) {
+	if violations := r.Violations(password); len(violations) > 0 {
+		return false, &violations[0]
+	}
+
+	return true, nil
+}
+
+func (r *SequenceRule) Violations(password string) []Violation {
+	runes := []rune(password)
+	for i := range runes {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+
+	sequence := longestRun(runes, func(previous, char rune) int {
+		if !r.sequenceChar(previous) || !r.sequenceChar(char) || sequenceClass(previous) != sequenceClass(char) {
+			return 0
+		}
+		if delta := int(char - previous); delta == 1 || delta == -1 {
+			return delta
+		}
+		return 0
+	})
+
+	if len([]rune(sequence)) > r.MaxLength {
+		return []Violation{newViolation(r.Name(), CodeContainsSequence, r.MaxLength, len([]rune(sequence)), map[string]any{"max": r.MaxLength, "sequence": sequence})}
+	}
+
+	return nil
+}
+
+func (r *SequenceRule) sequenceChar(char rune) bool {
+	switch sequenceClass(char) {
+	case 1:
+		return r.Alphabetic
+	case 3:
+		return r.Numeric
+	default:
+		return false
+	}
+}
+
+// longestRun returns the longest substring of runes in which every character
+// continues from the previous one. step returns 0 when char does not continue
+// from previous and otherwise a direction; a run keeps the direction of its
+// first step, so "aba" is not a run of three.
+func longestRun(runes []rune, step func(previous, char rune) int) string {
+	if len(runes) == 0 {
+		return ""
+	}
+
+	bestStart, bestLength := 0, 1
+	start, direction := 0, 0
+	for i := 1; i < len(runes); i++ {
+		d := step(runes[i-1], runes[i])
+		switch {
+		case d == 0:
+			start, direction = i, 0
+		case direction == 0 || d == direction:
+			direction = d
+		default:
+			start, direction = i-1, d
+		}
+		if length := i - start + 1; length > bestLength {
+			bestStart, bestLength = start, length
+		}
+	}
+	return string(runes[bestStart : bestStart+bestLength])
+}
@@ -0,0 +1,83 @@
+package rule
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSequenceRuleBuilder(t *testing.T) {
+	r := NewSequenceRuleBuilder().WithMaxLength(3).WithNumeric(false).Build()
+	if r.MaxLength != 3 || !r.Alphabetic || r.Numeric {
+		t.Errorf("SequenceRule = %+v, want MaxLength 3, alphabetic only", r)
+	}
+
+	if r := NewSequenceRuleBuilder().Build(); !reflect.DeepEqual(r, NewSequenceRule(3)) {
+		t.Errorf("NewSequenceRuleBuilder().Build() = %+v, want NewSequenceRule(3)", r)
+	}
+	if valid, err := NewSequenceRuleBuilder().Build().Validate("Tr0ub4dor&3"); !valid || err != nil {
+		t.Errorf("default SequenceRule Validate() = %v, %v, want true, nil", valid, err)
+	}
+}
+
+func TestSequenceRule_Validate(t *testing.T) {
+	r := NewSequenceRule(3)
+	testCases := []struct {
+		password string
+		sequence string
+	}{
+		{password: "Abcdefg1!", sequence: "abcdefg"},
+		{password: "xx4321yy", sequence: "4321"},
+		{password: "ZyXw!1", sequence: "zyxw"},
+		{password: "ab1234ba", sequence: "1234"},
+	}
+
+	for _, tc := range testCases {
+		valid, err := r.Validate(tc.password)
+		if valid || !errors.Is(err, ErrContainsSequence) {
+			t.Errorf("Validate(%q) = %v, %v, want false, ErrContainsSequence", tc.password, valid, err)
+			continue
+		}
+		var violation *Violation
+		if errors.As(err, &violation) && violation.Params["sequence"] != tc.sequence {
+			t.Errorf("Validate(%q) sequence = %v, want %q", tc.password, violation.Params["sequence"], tc.sequence)
+		}
+	}
+
+	for _, password := range []string{"", "abc!123", "abab1212", "az09", "xyz{|}"} {
+		if valid, err := r.Validate(password); !valid || err != nil {
+			t.Errorf("Validate(%q) = %v, %v, want true, nil", password, valid, err)
+		}
+	}
+}
+
+func TestSequenceRule_Classes(t *testing.T) {
+	alphabetic := NewSequenceRuleBuilder().WithMaxLength(2).WithNumeric(false).Build()
+	if valid, _ := alphabetic.Validate("x1234"); !valid {
+		t.Error("numeric sequences should be allowed when Numeric is false")
+	}
+	if valid, _ := alphabetic.Validate("xabc"); valid {
+		t.Error("alphabetic sequences should be rejected when Alphabetic is true")
+	}
+}
+
+func TestLongestRun(t *testing.T) {
+	step := func(previous, char rune) int {
+		if d := int(char - previous); d == 1 || d == -1 {
+			return d
+		}
+		return 0
+	}
+	testCases := map[string]string{
+		"":        "",
+		"a":       "a",
+		"abcba":   "abc",
+		"xcbabcd": "abcd",
+		"aba":     "ab",
+	}
+	for input, want := range testCases {
+		if got := longestRun([]rune(input), step); got != want {
+			t.Errorf("longestRun(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,558 @@
+package rule
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Guess rates used for crack time estimates, in guesses per second.
+const (
+	OnlineThrottlingRate   = 100.0 / 3600
+	OnlineNoThrottlingRate = 10.0
+	OfflineSlowHashingRate = 1e4
+	OfflineFastHashingRate = 1e10
+)
+
+const (
+	// maxStrengthLength bounds the work done on very long inputs; runes past
+	// it are ignored, which can only underestimate the strength.
+	maxStrengthLength = 256
+
+	bruteforceCardinality           = 10
+	minSubmatchGuessesSingleChar    = 10
+	minSubmatchGuessesMultiChar     = 50
+	minGuessesBeforeGrowingSequence = 10000
+	minYearSpace                    = 20
+)
+
+// referenceYear is the year dates and years in passwords are compared to.
+var referenceYear = time.Now().Year()
+
+// Strength is the result of EstimateStrength. Score ranges from 0 (too
+// guessable) to 4 (very unguessable):
+//
+//	0: fewer than 10^3 guesses
+//	1: fewer than 10^6 guesses
+//	2: fewer than 10^8 guesses
+//	3: fewer than 10^10 guesses
+//	4: 10^10 guesses or more
+type Strength struct {
+	Guesses      float64
+	GuessesLog10 float64
+	Score        int
+	CrackTimes   CrackTimes
+	Sequence     []Match
+	Feedback     Feedback
+}
+
+// CrackTimes holds the estimated seconds needed to guess a password under
+// different attack scenarios.
+type CrackTimes struct {
+	OnlineThrottling   float64
+	OnlineNoThrottling float64
+	OfflineSlowHashing float64
+	OfflineFastHashing float64
+}
+
+type Feedback struct {
+	Warning     string
+	Suggestions []string
+}
+
+// EstimateStrength estimates how many guesses an attacker who knows common
+// passwords, words, names, keyboard walks, repeats, sequences and dates needs
+// to find password. userInputs are treated as an extra dictionary, e.g. the
+// user's name or email.
+func EstimateStrength(password string, userInputs ...string) *Strength {
+	runes := []rune(password)
+	if len(runes) > maxStrengthLength {
+		runes = runes[:maxStrengthLength]
+	}
+
+	dictionaries := defaultDictionaries()
+	if len(userInputs) > 0 {
+		dictionaries = append(dictionaries[:len(dictionaries):len(dictionaries)], newRankedDictionary(DictionaryUserInputs, userInputs))
+	}
+
+	result := mostGuessableSequence(runes, omnimatch(runes, dictionaries))
+	s := &Strength{
+		Guesses:      result.guesses,
+		GuessesLog10: math.Log10(result.guesses),
+		Score:        guessesToScore(result.guesses),
+		Sequence:     result.sequence,
+		CrackTimes: CrackTimes{
+			OnlineThrottling:   result.guesses / OnlineThrottlingRate,
+			OnlineNoThrottling: result.guesses / OnlineNoThrottlingRate,
+			OfflineSlowHashing: result.guesses / OfflineSlowHashingRate,
+			OfflineFastHashing: result.guesses / OfflineFastHashingRate,
+		},
+	}
+	s.Feedback = feedbackFor(s.Score, s.Sequence)
+	return s
+}
+
+func guessesToScore(guesses float64) int {
+	const delta = 5
+	switch {
+	case guesses < 1e3+delta:
+		return 0
+	case guesses < 1e6+delta:
+		return 1
+	case guesses < 1e8+delta:
+		return 2
+	case guesses < 1e10+delta:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// DisplayCrackTime renders seconds as a rough human-readable duration such as
+// "3 hours" or "centuries".
+func DisplayCrackTime(seconds float64) string {
+	const (
+		minute  = 60
+		hour    = minute * 60
+		day     = hour * 24
+		month   = day * 31
+		year    = month * 12
+		century = year * 100
+	)
+
+	unit := func(n float64, name string) string {
+		rounded := int64(math.Round(n))
+		if rounded != 1 {
+			name += "s"
+		}
+		return fmt.Sprintf("%d %s", rounded, name)
+	}
+
+	switch {
+	case seconds < 1:
+		return "less than a second"
+	case seconds < minute:
+		return unit(seconds, "second")
+	case seconds < hour:
+		return unit(seconds/minute, "minute")
+	case seconds < day:
+		return unit(seconds/hour, "hour")
+	case seconds < month:
+		return unit(seconds/day, "day")
+	case seconds < year:
+		return unit(seconds/month, "month")
+	case seconds < century:
+		return unit(seconds/year, "year")
+	default:
+		return "centuries"
+	}
+}
+
+type guessResult struct {
+	guesses  float64
+	sequence []Match
+}
+
+type optimalEntry struct {
+	match   Match
+	product float64
+	guesses float64
+}
+
+// mostGuessableSequence finds the sequence of non-overlapping matches, with
+// brute force filling the gaps, that minimizes
+//
+//	l! * product(match guesses) + minGuessesBeforeGrowingSequence^(l-1)
+//
+// where l is the number of matches. The factorial accounts for the attacker
+// not knowing the order of the patterns and the additive term penalizes
+// splitting a password into many short matches.
+func mostGuessableSequence(password []rune, matches []Match) guessResult {
+	n := len(password)
+	if n == 0 {
+		return guessResult{guesses: 1}
+	}
+
+	byEnd := make([][]Match, n)
+	for _, m := range matches {
+		byEnd[m.J] = append(byEnd[m.J], m)
+	}
+	optimal := make([]map[int]optimalEntry, n)
+	for k := range optimal {
+		optimal[k] = map[int]optimalEntry{}
+	}
+
+	update := func(m Match, l int) {
+		k := m.J
+		m.Guesses = estimateGuesses(m, n)
+		product := m.Guesses
+		if l > 1 {
+			product *= optimal[m.I-1][l-1].product
+		}
+		guesses := math.Gamma(float64(l+1))*product + math.Pow(minGuessesBeforeGrowingSequence, float64(l-1))
+		for competingL, competing := range optimal[k] {
+			if competingL <= l && competing.guesses <= guesses {
+				return
+			}
+		}
+		optimal[k][l] = optimalEntry{match: m, product: product, guesses: guesses}
+	}
+
+	bruteforce := func(i, j int) Match {
+		return Match{Pattern: PatternBruteforce, I: i, J: j, Token: string(password[i : j+1])}
+	}
+
+	for k := 0; k < n; k++ {
+		for _, m := range byEnd[k] {
+			if m.I == 0 {
+				update(m, 1)
+				continue
+			}
+			for l := range optimal[m.I-1] {
+				update(m, l+1)
+			}
+		}
+
+		update(bruteforce(0, k), 1)
+		for i := 1; i <= k; i++ {
+			for l, last := range optimal[i-1] {
+				// Adjacent brute force matches are never better than a
+				// single longer one.
+				if last.match.Pattern == PatternBruteforce {
+					continue
+				}
+				update(bruteforce(i, k), l+1)
+			}
+		}
+	}
+
+	bestL := 0
+	best := math.Inf(1)
+	for l, entry := range optimal[n-1] {
+		if entry.guesses < best || (entry.guesses == best && l < bestL) {
+			bestL, best = l, entry.guesses
+		}
+	}
+
+	sequence := make([]Match, bestL)
+	for k, l := n-1, bestL; k >= 0 && l > 0; l-- {
+		m := optimal[k][l].match
+		sequence[l-1] = m
+		k = m.I - 1
+	}
+	return guessResult{guesses: best, sequence: sequence}
+}
+
+// estimateGuesses returns the guesses needed for m within a password of
+// passwordLength runes. Matches that are only part of the password are given
+// a floor, since an attacker also has to guess where they are.
+func estimateGuesses(m Match, passwordLength int) float64 {
+	length := m.J - m.I + 1
+	minGuesses := 1.0
+	if length < passwordLength {
+		minGuesses = minSubmatchGuessesMultiChar
+		if length == 1 {
+			minGuesses = minSubmatchGuessesSingleChar
+		}
+	}
+
+	var guesses float64
+	switch m.Pattern {
+	case PatternDictionary:
+		guesses = dictionaryGuesses(m)
+	case PatternSpatial:
+		guesses = spatialGuesses(m)
+	case PatternRepeat:
+		guesses = m.BaseGuesses * float64(m.Repeats)
+	case PatternSequence:
+		guesses = sequenceGuesses(m)
+	case PatternDate:
+		guesses = dateGuesses(m)
+	default:
+		guesses = bruteforceGuesses(length)
+	}
+	return math.Max(guesses, minGuesses)
+}
+
+func bruteforceGuesses(length int) float64 {
+	guesses := math.Pow(bruteforceCardinality, float64(length))
+	minGuesses := float64(minSubmatchGuessesMultiChar + 1)
+	if length == 1 {
+		minGuesses = minSubmatchGuessesSingleChar + 1
+	}
+	return math.Max(guesses, minGuesses)
+}
+
+func dictionaryGuesses(m Match) float64 {
+	guesses := float64(m.Rank) * uppercaseVariations(m.Token) * l33tVariations(m)
+	if m.Reversed {
+		guesses *= 2
+	}
+	return guesses
+}
+
+// uppercaseVariations is the number of capitalizations an attacker tries for
+// token: common ones (first, last or all letters uppercase) count double,
+// anything else counts every way of placing its uppercase letters.
+func uppercaseVariations(token string) float64 {
+	runes := []rune(token)
+	var upper, lower int
+	for _, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	if upper == 0 {
+		return 1
+	}
+	if lower == 0 || (upper == 1 && (unicode.IsUpper(runes[0]) || unicode.IsUpper(runes[len(runes)-1]))) {
+		return 2
+	}
+
+	variations := 0.0
+	for i := 1; i <= min(upper, lower); i++ {
+		variations += binomial(upper+lower, i)
+	}
+	return variations
+}
+
+func l33tVariations(m Match) float64 {
+	if !m.L33t {
+		return 1
+	}
+
+	lower := strings.ToLower(m.Token)
+	variations := 1.0
+	for sub, letter := range m.Substitutions {
+		subbed := strings.Count(lower, string(sub))
+		unsubbed := strings.Count(lower, string(letter))
+		if subbed == 0 || unsubbed == 0 {
+			variations *= 2
+			continue
+		}
+		possibilities := 0.0
+		for i := 1; i <= min(subbed, unsubbed); i++ {
+			possibilities += binomial(subbed+unsubbed, i)
+		}
+		variations *= possibilities
+	}
+	return variations
+}
+
+func spatialGuesses(m Match) float64 {
+	var graph *keyboardGraph
+	for _, g := range keyboardGraphs() {
+		if g.layout.Name == m.Layout {
+			graph = g
+		}
+	}
+	if graph == nil {
+		return bruteforceGuesses(m.J - m.I + 1)
+	}
+
+	starts := float64(graph.startingPositions())
+	degree := graph.averageDegree()
+	length := len([]rune(m.Token))
+
+	guesses := 0.0
+	for i := 2; i <= length; i++ {
+		for j := 1; j <= min(m.Turns, i-1); j++ {
+			guesses += binomial(i-1, j-1) * starts * math.Pow(degree, float64(j))
+		}
+	}
+
+	if m.Shifted > 0 {
+		unshifted := length - m.Shifted
+		if unshifted == 0 {
+			guesses *= 2
+		} else {
+			variations := 0.0
+			for i := 1; i <= min(m.Shifted, unshifted); i++ {
+				variations += binomial(m.Shifted+unshifted, i)
+			}
+			guesses *= variations
+		}
+	}
+	return guesses
+}
+
+func sequenceGuesses(m Match) float64 {
+	runes := []rune(m.Token)
+	var base float64
+	switch {
+	case strings.ContainsRune("aAzZ019", runes[0]):
+		base = 4
+	case unicode.IsDigit(runes[0]):
+		base = 10
+	default:
+		base = 26
+	}
+	if !m.Ascending {
+		base *= 2
+	}
+	return base * float64(len(runes))
+}
+
+func dateGuesses(m Match) float64 {
+	yearSpace := float64(max(abs(m.Year-referenceYear), minYearSpace))
+	if m.Month == 0 {
+		return yearSpace
+	}
+	guesses := yearSpace * 365
+	if m.Separator != "" {
+		guesses *= 4
+	}
+	return guesses
+}
+
+func binomial(n, k int) float64 {
+	if k > n {
+		return 0
+	}
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result *= float64(n-k+i) / float64(i)
+	}
+	return result
+}
+
+func feedbackFor(score int, sequence []Match) Feedback {
+	if len(sequence) == 0 {
+		return Feedback{Suggestions: []string{
+			"Use a few words, avoid common phrases",
+			"No need for symbols, digits, or uppercase letters",
+		}}
+	}
+	if score > 2 {
+		return Feedback{}
+	}
+
+	longest := sequence[0]
+	for _, m := range sequence[1:] {
+		if len(m.Token) > len(longest.Token) {
+			longest = m
+		}
+	}
+
+	feedback := matchFeedback(longest, len(sequence) == 1)
+	feedback.Suggestions = append([]string{"Add another word or two. Uncommon words are better."}, feedback.Suggestions...)
+	return feedback
+}
+
+func matchFeedback(m Match, soleMatch bool) Feedback {
+	switch m.Pattern {
+	case PatternDictionary:
+		return dictionaryFeedback(m, soleMatch)
+	case PatternSpatial:
+		warning := "Short keyboard patterns are easy to guess"
+		if m.Turns == 1 {
+			warning = "Straight rows of keys are easy to guess"
+		}
+		return Feedback{Warning: warning, Suggestions: []string{"Use a longer keyboard pattern with more turns"}}
+	case PatternRepeat:
+		warning := `Repeats like "abcabcabc" are only slightly harder to guess than "abc"`
+		if len([]rune(m.BaseToken)) == 1 {
+			warning = `Repeats like "aaa" are easy to guess`
+		}
+		return Feedback{Warning: warning, Suggestions: []string{"Avoid repeated words and characters"}}
+	case PatternSequence:
+		return Feedback{Warning: "Sequences like abc or 6543 are easy to guess", Suggestions: []string{"Avoid sequences"}}
+	case PatternDate:
+		if m.Month == 0 {
+			return Feedback{Warning: "Recent years are easy to guess", Suggestions: []string{"Avoid recent years", "Avoid years that are associated with you"}}
+		}
+		return Feedback{Warning: "Dates are often easy to guess", Suggestions: []string{"Avoid dates and years that are associated with you"}}
+	default:
+		return Feedback{}
+	}
+}
+
+func dictionaryFeedback(m Match, soleMatch bool) Feedback {
+	var feedback Feedback
+	switch m.Dictionary {
+	case DictionaryPasswords:
+		switch {
+		case soleMatch && !m.L33t && !m.Reversed && m.Rank <= 10:
+			feedback.Warning = "This is a top-10 common password"
+		case soleMatch && !m.L33t && !m.Reversed && m.Rank <= 100:
+			feedback.Warning = "This is a top-100 common password"
+		case soleMatch && !m.L33t && !m.Reversed:
+			feedback.Warning = "This is a very common password"
+		case math.Log10(m.Guesses) <= 4:
+			feedback.Warning = "This is similar to a commonly used password"
+		}
+	case DictionaryEnglish:
+		if soleMatch {
+			feedback.Warning = "A word by itself is easy to guess"
+		}
+	case DictionaryNames, DictionarySurnames:
+		feedback.Warning = "Common names and surnames are easy to guess"
+		if soleMatch {
+			feedback.Warning = "Names and surnames by themselves are easy to guess"
+		}
+	case DictionaryUserInputs:
+		feedback.Warning = "Avoid personal information such as your name or email"
+	}
+
+	runes := []rune(m.Token)
+	switch {
+	case unicode.IsUpper(runes[0]) && strings.ToLower(string(runes[1:])) == string(runes[1:]):
+		feedback.Suggestions = append(feedback.Suggestions, "Capitalization doesn't help very much")
+	case strings.ToUpper(m.Token) == m.Token && strings.ToLower(m.Token) != m.Token:
+		feedback.Suggestions = append(feedback.Suggestions, "All-uppercase is almost as easy to guess as all-lowercase")
+	}
+	if m.Reversed && len(runes) >= 4 {
+		feedback.Suggestions = append(feedback.Suggestions, "Reversed words aren't much harder to guess")
+	}
+	if m.L33t {
+		feedback.Suggestions = append(feedback.Suggestions, "Predictable substitutions like '@' instead of 'a' don't help very much")
+	}
+	return feedback
+}
+
+type MinStrengthRule struct {
+	MinScore   int
+	UserInputs []string
+}
+
+func NewMinStrengthRule(minScore int, userInputs ...string) *MinStrengthRule {
+	return &MinStrengthRule{MinScore: minScore, UserInputs: userInputs}
+}
+
+func (r *MinStrengthRule) Name() string {
+	return "min_strength"
+}
+
+func (r *MinStrengthRule) Validate(password string) (bool, error) {
+	return r.ValidateWithContext(password, nil)
+}
+
+// ValidateWithContext also treats the attributes of ctx as user inputs.
+func (r *MinStrengthRule) ValidateWithContext(password string, ctx *ValidationContext) (bool, error) {
+	if violations := r.violations(password, append(r.UserInputs[:len(r.UserInputs):len(r.UserInputs)], ctx.Values()...)); len(violations) > 0 {
+		return false, &violations[0]
+	}
+
+	return true, nil
+}
+
+func (r *MinStrengthRule) Violations(password string) []Violation {
+	return r.violations(password, r.UserInputs)
+}
+
+func (r *MinStrengthRule) violations(password string, userInputs []string) []Violation {
+	strength := EstimateStrength(password, userInputs...)
+	if strength.Score < r.MinScore {
+		return []Violation{newViolation(r.Name(), CodeWeakPassword, r.MinScore, strength.Score, map[string]any{
+			"min":     r.MinScore,
+			"warning": strength.Feedback.Warning,
+		})}
+	}
+
+	return nil
+}
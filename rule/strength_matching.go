@@ -0,0 +1,557 @@
+package rule
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	PatternDictionary = "dictionary"
+	PatternSpatial    = "spatial"
+	PatternRepeat     = "repeat"
+	PatternSequence   = "sequence"
+	PatternDate       = "date"
+	PatternBruteforce = "bruteforce"
+)
+
+// Match is a part of a password recognized by the strength estimator. I and J
+// are the indices of its first and last rune; the remaining fields are set
+// depending on Pattern.
+type Match struct {
+	Pattern string
+	I, J    int
+	Token   string
+	Guesses float64
+
+	// Dictionary matches.
+	Dictionary    string
+	MatchedWord   string
+	Rank          int
+	Reversed      bool
+	L33t          bool
+	Substitutions map[rune]rune
+
+	// Spatial matches.
+	Layout  string
+	Turns   int
+	Shifted int
+
+	// Repeat matches.
+	BaseToken   string
+	BaseGuesses float64
+	Repeats     int
+
+	// Sequence matches.
+	Ascending bool
+
+	// Date matches. Month and Day are zero for a bare year.
+	Year, Month, Day int
+	Separator        string
+}
+
+// l33tTable maps letters to the characters commonly substituted for them.
+var l33tTable = map[rune]string{
+	'a': "4@",
+	'b': "8",
+	'c': "({[<",
+	'e': "3",
+	'g': "69",
+	'i': "1!|",
+	'l': "1|7",
+	'o': "0",
+	's': "$5",
+	't': "+7",
+	'x': "%",
+	'z': "2",
+}
+
+// maxL33tSubstitutions bounds the number of substitution combinations tried
+// for a single password.
+const maxL33tSubstitutions = 64
+
+func omnimatch(password []rune, dictionaries []*rankedDictionary) []Match {
+	var matches []Match
+	matches = append(matches, dictionaryMatches(password, dictionaries)...)
+	matches = append(matches, reverseDictionaryMatches(password, dictionaries)...)
+	matches = append(matches, l33tMatches(password, dictionaries)...)
+	for _, graph := range keyboardGraphs() {
+		matches = append(matches, spatialMatches(password, graph)...)
+	}
+	matches = append(matches, repeatMatches(password, dictionaries)...)
+	matches = append(matches, sequenceMatches(password)...)
+	matches = append(matches, dateMatches(password)...)
+	sort.SliceStable(matches, func(a, b int) bool {
+		if matches[a].I != matches[b].I {
+			return matches[a].I < matches[b].I
+		}
+		return matches[a].J < matches[b].J
+	})
+	return matches
+}
+
+func dictionaryMatches(password []rune, dictionaries []*rankedDictionary) []Match {
+	lower := make([]rune, len(password))
+	for i, r := range password {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	var matches []Match
+	for _, d := range dictionaries {
+		for i := range lower {
+			for j := i; j < len(lower) && j-i < d.maxLength; j++ {
+				word := string(lower[i : j+1])
+				rank, ok := d.ranks[word]
+				if !ok {
+					continue
+				}
+				matches = append(matches, Match{
+					Pattern:     PatternDictionary,
+					I:           i,
+					J:           j,
+					Token:       string(password[i : j+1]),
+					Dictionary:  d.name,
+					MatchedWord: word,
+					Rank:        rank,
+				})
+			}
+		}
+	}
+	return matches
+}
+
+func reverseDictionaryMatches(password []rune, dictionaries []*rankedDictionary) []Match {
+	n := len(password)
+	reversed := make([]rune, n)
+	for i, r := range password {
+		reversed[n-1-i] = r
+	}
+
+	matches := dictionaryMatches(reversed, dictionaries)
+	for k := range matches {
+		m := &matches[k]
+		m.I, m.J = n-1-m.J, n-1-m.I
+		m.Token = string(password[m.I : m.J+1])
+		m.Reversed = true
+	}
+	return matches
+}
+
+func l33tMatches(password []rune, dictionaries []*rankedDictionary) []Match {
+	type key struct {
+		i, j int
+		word string
+	}
+	seen := map[key]bool{}
+
+	var matches []Match
+	for _, substitutions := range l33tSubstitutions(password) {
+		subbed := make([]rune, len(password))
+		for i, r := range password {
+			if letter, ok := substitutions[unicode.ToLower(r)]; ok {
+				subbed[i] = letter
+			} else {
+				subbed[i] = r
+			}
+		}
+
+		for _, m := range dictionaryMatches(subbed, dictionaries) {
+			token := password[m.I : m.J+1]
+			used := map[rune]rune{}
+			for _, r := range token {
+				if letter, ok := substitutions[unicode.ToLower(r)]; ok {
+					used[unicode.ToLower(r)] = letter
+				}
+			}
+			// Single characters such as "1" for "i" are better described
+			// as brute force.
+			if len(used) == 0 || len(token) == 1 {
+				continue
+			}
+			k := key{m.I, m.J, m.MatchedWord}
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+
+			m.Token = string(token)
+			m.L33t = true
+			m.Substitutions = used
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// l33tSubstitutions returns the possible ways of reading the l33t characters
+// present in password, each as a map from substituted character to letter.
+func l33tSubstitutions(password []rune) []map[rune]rune {
+	candidates := map[rune][]rune{}
+	var present []rune
+	for _, r := range password {
+		r = unicode.ToLower(r)
+		if _, ok := candidates[r]; ok {
+			continue
+		}
+		var letters []rune
+		for letter, subs := range l33tTable {
+			if strings.ContainsRune(subs, r) {
+				letters = append(letters, letter)
+			}
+		}
+		if len(letters) == 0 {
+			continue
+		}
+		sort.Slice(letters, func(a, b int) bool { return letters[a] < letters[b] })
+		candidates[r] = letters
+		present = append(present, r)
+	}
+	if len(present) == 0 {
+		return nil
+	}
+
+	combinations := []map[rune]rune{{}}
+	for _, sub := range present {
+		var next []map[rune]rune
+		for _, combination := range combinations {
+			for _, letter := range candidates[sub] {
+				if len(next) == maxL33tSubstitutions {
+					break
+				}
+				extended := make(map[rune]rune, len(combination)+1)
+				for k, v := range combination {
+					extended[k] = v
+				}
+				extended[sub] = letter
+				next = append(next, extended)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+func spatialMatches(password []rune, graph *keyboardGraph) []Match {
+	var matches []Match
+	i := 0
+	for i < len(password)-1 {
+		j := i + 1
+		lastDirection := -1
+		turns := 0
+		shifted := 0
+		if graph.shifted(password[i]) {
+			shifted++
+		}
+		for j < len(password) {
+			direction := graph.direction(password[j-1], password[j])
+			if direction < 0 {
+				break
+			}
+			if direction != lastDirection {
+				turns++
+				lastDirection = direction
+			}
+			if graph.shifted(password[j]) {
+				shifted++
+			}
+			j++
+		}
+		if j-i > 2 {
+			matches = append(matches, Match{
+				Pattern: PatternSpatial,
+				I:       i,
+				J:       j - 1,
+				Token:   string(password[i:j]),
+				Layout:  graph.layout.Name,
+				Turns:   turns,
+				Shifted: shifted,
+			})
+		}
+		i = j
+	}
+	return matches
+}
+
+// repeatMatches finds runs of a repeated base token, preferring at each
+// position the repetition that covers the most characters.
+func repeatMatches(password []rune, dictionaries []*rankedDictionary) []Match {
+	var matches []Match
+	i := 0
+	for i < len(password) {
+		bestUnit, bestRepeats := 0, 0
+		for unit := 1; i+2*unit <= len(password); unit++ {
+			repeats := 1
+			for i+(repeats+1)*unit <= len(password) && equalRunes(password[i:i+unit], password[i+repeats*unit:i+(repeats+1)*unit]) {
+				repeats++
+			}
+			if repeats > 1 && unit*repeats > bestUnit*bestRepeats {
+				bestUnit, bestRepeats = unit, repeats
+			}
+		}
+		if bestRepeats == 0 {
+			i++
+			continue
+		}
+
+		base := password[i : i+bestUnit]
+		end := i + bestUnit*bestRepeats
+		matches = append(matches, Match{
+			Pattern:     PatternRepeat,
+			I:           i,
+			J:           end - 1,
+			Token:       string(password[i:end]),
+			BaseToken:   string(base),
+			BaseGuesses: mostGuessableSequence(base, omnimatch(base, dictionaries)).guesses,
+			Repeats:     bestRepeats,
+		})
+		i = end
+	}
+	return matches
+}
+
+func equalRunes(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// maxSequenceDelta is the largest step between consecutive characters that is
+// still considered a sequence, e.g. "aceg" or "13579".
+const maxSequenceDelta = 5
+
+func sequenceMatches(password []rune) []Match {
+	var matches []Match
+	i := 0
+	for i < len(password)-1 {
+		delta := password[i+1] - password[i]
+		if delta == 0 || delta > maxSequenceDelta || delta < -maxSequenceDelta || sequenceClass(password[i]) == 0 || sequenceClass(password[i]) != sequenceClass(password[i+1]) {
+			i++
+			continue
+		}
+
+		j := i + 1
+		for j+1 < len(password) && password[j+1]-password[j] == delta && sequenceClass(password[j+1]) == sequenceClass(password[i]) {
+			j++
+		}
+		if j-i+1 >= 3 {
+			matches = append(matches, Match{
+				Pattern:   PatternSequence,
+				I:         i,
+				J:         j,
+				Token:     string(password[i : j+1]),
+				Ascending: delta > 0,
+			})
+		}
+		i = j
+	}
+	return matches
+}
+
+func sequenceClass(r rune) int {
+	switch {
+	case r >= 'a' && r <= 'z':
+		return 1
+	case r >= 'A' && r <= 'Z':
+		return 2
+	case r >= '0' && r <= '9':
+		return 3
+	default:
+		return 0
+	}
+}
+
+const (
+	dateMinYear = 1000
+	dateMaxYear = 2050
+)
+
+// dateSplits lists, per token length, the positions at which a digit-only
+// token is cut into its three date components.
+var dateSplits = map[int][][2]int{
+	4: {{1, 2}, {2, 3}},
+	5: {{1, 3}, {2, 3}},
+	6: {{1, 2}, {2, 4}, {4, 5}},
+	7: {{1, 3}, {2, 3}, {4, 5}, {4, 6}},
+	8: {{2, 4}, {4, 6}},
+}
+
+func dateMatches(password []rune) []Match {
+	var matches []Match
+	for i := range password {
+		for j := i + 3; j < len(password) && j-i < 10; j++ {
+			token := string(password[i : j+1])
+			if m, ok := dateWithoutSeparator(token); ok {
+				m.I, m.J = i, j
+				matches = append(matches, m)
+			} else if m, ok := dateWithSeparator(token); ok {
+				m.I, m.J = i, j
+				matches = append(matches, m)
+			}
+			if j-i == 3 && isDigits(token) {
+				if year, _ := strconv.Atoi(token); year >= 1900 && year <= 2049 {
+					matches = append(matches, Match{Pattern: PatternDate, I: i, J: j, Token: token, Year: year})
+				}
+			}
+		}
+	}
+
+	// Drop full dates contained in another full date, such as "1/1/91"
+	// inside "11/1/91".
+	var filtered []Match
+	for _, m := range matches {
+		contained := false
+		for _, other := range matches {
+			if other.Month == 0 || m.Month == 0 || (other.I == m.I && other.J == m.J) {
+				continue
+			}
+			if other.I <= m.I && other.J >= m.J {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
+
+func dateWithoutSeparator(token string) (Match, bool) {
+	splits, ok := dateSplits[len(token)]
+	if !ok || !isDigits(token) {
+		return Match{}, false
+	}
+
+	var best Match
+	found := false
+	for _, split := range splits {
+		a, _ := strconv.Atoi(token[:split[0]])
+		b, _ := strconv.Atoi(token[split[0]:split[1]])
+		c, _ := strconv.Atoi(token[split[1]:])
+		year, month, day, ok := mapIntsToDate(a, b, c)
+		if !ok {
+			continue
+		}
+		if !found || abs(year-referenceYear) < abs(best.Year-referenceYear) {
+			best = Match{Pattern: PatternDate, Token: token, Year: year, Month: month, Day: day}
+			found = true
+		}
+	}
+	return best, found
+}
+
+func dateWithSeparator(token string) (Match, bool) {
+	if len(token) < 6 {
+		return Match{}, false
+	}
+	var separator rune
+	for _, r := range token {
+		if r < '0' || r > '9' {
+			separator = r
+			break
+		}
+	}
+	if !strings.ContainsRune(" /\\_.-", separator) {
+		return Match{}, false
+	}
+
+	parts := strings.Split(token, string(separator))
+	if len(parts) != 3 || len(parts[0]) > 4 || len(parts[1]) > 2 || len(parts[2]) > 4 {
+		return Match{}, false
+	}
+	var ints [3]int
+	for k, part := range parts {
+		if part == "" || !isDigits(part) {
+			return Match{}, false
+		}
+		ints[k], _ = strconv.Atoi(part)
+	}
+	year, month, day, ok := mapIntsToDate(ints[0], ints[1], ints[2])
+	if !ok {
+		return Match{}, false
+	}
+	return Match{Pattern: PatternDate, Token: token, Year: year, Month: month, Day: day, Separator: string(separator)}, true
+}
+
+// mapIntsToDate interprets three integers as a day, month and year in any of
+// the common orders, expanding two-digit years.
+func mapIntsToDate(a, b, c int) (year, month, day int, ok bool) {
+	if b > 31 || b <= 0 {
+		return 0, 0, 0, false
+	}
+	over12, over31, under1 := 0, 0, 0
+	for _, n := range []int{a, b, c} {
+		if (n > 99 && n < dateMinYear) || n > dateMaxYear {
+			return 0, 0, 0, false
+		}
+		if n > 31 {
+			over31++
+		}
+		if n > 12 {
+			over12++
+		}
+		if n <= 0 {
+			under1++
+		}
+	}
+	if over31 >= 2 || over12 == 3 || under1 >= 2 {
+		return 0, 0, 0, false
+	}
+
+	candidates := [][3]int{{c, a, b}, {a, b, c}}
+	for _, candidate := range candidates {
+		if candidate[0] >= dateMinYear && candidate[0] <= dateMaxYear {
+			month, day, ok := mapIntsToMonthDay(candidate[1], candidate[2])
+			return candidate[0], month, day, ok
+		}
+	}
+	for _, candidate := range candidates {
+		if month, day, ok := mapIntsToMonthDay(candidate[1], candidate[2]); ok {
+			return twoToFourDigitYear(candidate[0]), month, day, true
+		}
+	}
+	return 0, 0, 0, false
+}
+
+func mapIntsToMonthDay(a, b int) (month, day int, ok bool) {
+	for _, pair := range [][2]int{{a, b}, {b, a}} {
+		if pair[0] >= 1 && pair[0] <= 31 && pair[1] >= 1 && pair[1] <= 12 {
+			return pair[1], pair[0], true
+		}
+	}
+	return 0, 0, false
+}
+
+func twoToFourDigitYear(year int) int {
+	switch {
+	case year > 99:
+		return year
+	case year > 50:
+		return 1900 + year
+	default:
+		return 2000 + year
+	}
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,141 @@
+package rule
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEstimateStrength_Patterns(t *testing.T) {
+	testCases := []struct {
+		name     string
+		password string
+		pattern  string
+	}{
+		{name: "Common password", password: "password", pattern: PatternDictionary},
+		{name: "Reversed word", password: "drowssap", pattern: PatternDictionary},
+		{name: "L33t", password: "p@ssw0rd", pattern: PatternDictionary},
+		{name: "Keyboard walk", password: "zxcvfr", pattern: PatternSpatial},
+		{name: "Repeat", password: "aaaaaaaa", pattern: PatternRepeat},
+		{name: "Sequence", password: "abcdefg", pattern: PatternSequence},
+		{name: "Date", password: "11/05/1987", pattern: PatternDate},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := EstimateStrength(tc.password)
+			if len(s.Sequence) != 1 || s.Sequence[0].Pattern != tc.pattern {
+				t.Fatalf("EstimateStrength(%q).Sequence = %+v, want a single %s match", tc.password, s.Sequence, tc.pattern)
+			}
+			if s.Score > 1 {
+				t.Errorf("EstimateStrength(%q).Score = %d, want at most 1", tc.password, s.Score)
+			}
+			if s.Feedback.Warning == "" {
+				t.Errorf("EstimateStrength(%q).Feedback.Warning is empty", tc.password)
+			}
+		})
+	}
+}
+
+func TestEstimateStrength_Score(t *testing.T) {
+	testCases := []struct {
+		password string
+		maxScore int
+		minScore int
+	}{
+		{password: "", maxScore: 0},
+		{password: "Password1!", maxScore: 1},
+		{password: "qwerty123", maxScore: 1},
+		{password: "correcthorsebatterystaple", minScore: 4, maxScore: 4},
+		{password: "xK9#mQ2$vL7!", minScore: 4, maxScore: 4},
+	}
+
+	for _, tc := range testCases {
+		s := EstimateStrength(tc.password)
+		if s.Score < tc.minScore || s.Score > tc.maxScore {
+			t.Errorf("EstimateStrength(%q).Score = %d, want between %d and %d", tc.password, s.Score, tc.minScore, tc.maxScore)
+		}
+	}
+}
+
+func TestEstimateStrength_UserInputs(t *testing.T) {
+	without := EstimateStrength("wissensalt2024")
+	with := EstimateStrength("wissensalt2024", "wissensalt")
+	if with.Guesses >= without.Guesses {
+		t.Errorf("user input should lower guesses: %f >= %f", with.Guesses, without.Guesses)
+	}
+	if with.Sequence[0].Dictionary != DictionaryUserInputs {
+		t.Errorf("first match dictionary = %q, want %q", with.Sequence[0].Dictionary, DictionaryUserInputs)
+	}
+}
+
+func TestEstimateStrength_CrackTimes(t *testing.T) {
+	s := EstimateStrength("Password1!")
+	if s.CrackTimes.OnlineThrottling <= s.CrackTimes.OnlineNoThrottling ||
+		s.CrackTimes.OnlineNoThrottling <= s.CrackTimes.OfflineSlowHashing ||
+		s.CrackTimes.OfflineSlowHashing <= s.CrackTimes.OfflineFastHashing {
+		t.Errorf("crack times should decrease with faster attacks: %+v", s.CrackTimes)
+	}
+	if got, want := s.CrackTimes.OfflineSlowHashing, s.Guesses/OfflineSlowHashingRate; got != want {
+		t.Errorf("OfflineSlowHashing = %f, want %f", got, want)
+	}
+}
+
+func TestDisplayCrackTime(t *testing.T) {
+	testCases := []struct {
+		seconds float64
+		want    string
+	}{
+		{seconds: 0.5, want: "less than a second"},
+		{seconds: 1, want: "1 second"},
+		{seconds: 3 * 3600, want: "3 hours"},
+		{seconds: 1e12, want: "centuries"},
+	}
+
+	for _, tc := range testCases {
+		if got := DisplayCrackTime(tc.seconds); got != tc.want {
+			t.Errorf("DisplayCrackTime(%f) = %q, want %q", tc.seconds, got, tc.want)
+		}
+	}
+}
+
+func TestMapIntsToDate(t *testing.T) {
+	year, month, day, ok := mapIntsToDate(11, 5, 87)
+	if !ok || year != 1987 || month != 5 || day != 11 {
+		t.Errorf("mapIntsToDate(11, 5, 87) = %d, %d, %d, %v, want 1987, 5, 11, true", year, month, day, ok)
+	}
+	if _, _, _, ok := mapIntsToDate(45, 45, 45); ok {
+		t.Error("mapIntsToDate(45, 45, 45) should not be a date")
+	}
+}
+
+func TestMinStrengthRule_Validate(t *testing.T) {
+	r := NewMinStrengthRule(3)
+
+	valid, err := r.Validate("Password1!")
+	if valid || !errors.Is(err, ErrWeakPassword) {
+		t.Errorf("Validate(%q) = %v, %v, want false, ErrWeakPassword", "Password1!", valid, err)
+	}
+	var violation *Violation
+	if errors.As(err, &violation) && violation.Params["warning"] == "" {
+		t.Error("violation should carry the feedback warning")
+	}
+
+	if valid, err := r.Validate("xK9#mQ2$vL7!"); !valid || err != nil {
+		t.Errorf("Validate(%q) = %v, %v, want true, nil", "xK9#mQ2$vL7!", valid, err)
+	}
+}
+
+func TestPaswotRule_WithMinStrength(t *testing.T) {
+	paswotRule := NewPaswotRuleBuilder().
+		WithLength(NewLengthRule(8, 16)).
+		WithMinStrength(NewMinStrengthRule(3)).
+		Build()
+
+	report := paswotRule.Report("Password1!")
+	if report.Valid() {
+		t.Fatal("Report(\"Password1!\") should not be valid")
+	}
+	if codes := report.Codes(); len(codes) != 1 || codes[0] != CodeWeakPassword {
+		t.Errorf("Report codes = %v, want [%s]", codes, CodeWeakPassword)
+	}
+}
@@ -0,0 +1,57 @@
+package rule
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
+)
+
+// LengthUnit selects how LengthRule measures a password. LengthBytes, the
+// default, counts UTF-8 bytes, so "pässwört" is 10 long; LengthCodePoints
+// counts it as 8, as does LengthGraphemes, which also counts a character
+// followed by combining marks or an emoji sequence as one.
+type LengthUnit int
+
+const (
+	LengthBytes LengthUnit = iota
+	LengthCodePoints
+	LengthGraphemes
+)
+
+func (u LengthUnit) Count(password string) int {
+	switch u {
+	case LengthCodePoints:
+		return utf8.RuneCountInString(password)
+	case LengthGraphemes:
+		return uniseg.GraphemeClusterCount(password)
+	default:
+		return len(password)
+	}
+}
+
+func (u LengthUnit) String() string {
+	switch u {
+	case LengthCodePoints:
+		return "code points"
+	case LengthGraphemes:
+		return "graphemes"
+	default:
+		return "bytes"
+	}
+}
+
+// NormalizeNFKC returns the NFKC normalization of password, as recommended by
+// NIST 800-63B before passwords are validated and hashed. It maps
+// compatibility characters such as full-width letters and ligatures to their
+// plain forms and composes characters with combining marks.
+func NormalizeNFKC(password string) string {
+	return norm.NFKC.String(password)
+}
+
+// isUnicodeSymbol reports whether char is punctuation or a symbol in any
+// script.
+func isUnicodeSymbol(char rune) bool {
+	return unicode.IsPunct(char) || unicode.IsSymbol(char)
+}
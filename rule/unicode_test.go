@@ -0,0 +1,65 @@
+package rule
+
+import (
+	"testing"
+)
+
+func TestLengthUnit_Count(t *testing.T) {
+	testCases := []struct {
+		password string
+		unit     LengthUnit
+		want     int
+	}{
+		{"pässwört", LengthBytes, 10},
+		{"pässwört", LengthCodePoints, 8},
+		{"pässwört", LengthGraphemes, 8},
+		{"pässwört", LengthCodePoints, 10},
+		{"pässwört", LengthGraphemes, 8},
+		{"🇮🇩👍🏽", LengthCodePoints, 4},
+		{"🇮🇩👍🏽", LengthGraphemes, 2},
+		{"", LengthGraphemes, 0},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.unit.Count(tc.password); got != tc.want {
+			t.Errorf("%s.Count(%q) = %d, want %d", tc.unit, tc.password, got, tc.want)
+		}
+	}
+}
+
+func TestNormalizeNFKC(t *testing.T) {
+	testCases := map[string]string{
+		"Ｐａｓｓｗｏｒｄ":  "Password",
+		"ﬁsh":       "fish",
+		"pässwort": "pässwort",
+		"plain":     "plain",
+	}
+
+	for input, want := range testCases {
+		if got := NormalizeNFKC(input); got != want {
+			t.Errorf("NormalizeNFKC(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestPaswotRule_NFKC(t *testing.T) {
+	paswotRule := NewPaswotRuleBuilder().
+		WithLength(NewLengthRuleBuilder().WithMin(4).WithMax(8).WithUnit(LengthCodePoints).Build()).
+		WithCharacter(NewCharacterRule(1, 1, 0, 0)).
+		WithNFKC(true).
+		Build()
+
+	if got := paswotRule.Normalize("ﬁsh"); got != "fish" {
+		t.Errorf("Normalize() = %q, want %q", got, "fish")
+	}
+
+	// Full-width letters are ASCII after normalization.
+	if report := paswotRule.Report("Ｐａｓｓｗｏｒｄ"); !report.Valid() {
+		t.Errorf("Report() = %v, want valid", report.Violations)
+	}
+
+	paswotRule.NFKC = false
+	if report := paswotRule.Report("Ｐａｓｓｗｏｒｄ"); report.Valid() {
+		t.Error("Report() without NFKC should reject full-width letters")
+	}
+}
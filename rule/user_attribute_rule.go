@@ -0,0 +1,178 @@
+package rule
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// minAttributeTokenLength is the shortest attribute word checked; shorter
+// words such as initials would reject too many passwords.
+const minAttributeTokenLength = 3
+
+// UserAttributeRule rejects passwords that contain or closely resemble the
+// username, email, display name or company name of the ValidationContext.
+// Comparisons ignore case and l33t substitutions and also check the reversed
+// password. A password is rejected when it shares MinSubstringLength
+// consecutive characters with an attribute word, or when its similarity to an
+// attribute word, 1 minus the edit distance divided by the longer length, is
+// at least MaxSimilarity. Words shorter than MinSubstringLength must instead
+// match a whole word of the password, delimited by other characters or a
+// change to uppercase and ignoring digits around it, so "lee" rejects
+// "Lee!2024" and "SkyLee42" but not "Sleeping#42".
+type UserAttributeRule struct {
+	MinSubstringLength int
+	MaxSimilarity      float64
+}
+
+func NewUserAttributeRule() *UserAttributeRule {
+	return &UserAttributeRule{MinSubstringLength: 4, MaxSimilarity: 0.7}
+}
+
+func (r *UserAttributeRule) Name() string {
+	return "user_attribute"
+}
+
+// Validate accepts every password, as there is nothing to compare with
+// without a ValidationContext.
+func (r *UserAttributeRule) Validate(password string) (bool, error) {
+	return r.ValidateWithContext(password, nil)
+}
+
+func (r *UserAttributeRule) ValidateWithContext(password string, ctx *ValidationContext) (bool, error) {
+	forms := passwordForms(password)
+	words := passwordWords(password)
+	for _, attribute := range ctx.attributes() {
+		for _, token := range attributeTokens(attribute.value) {
+			if len([]rune(token)) < minAttributeTokenLength {
+				continue
+			}
+			if r.resembles(forms, words, token) {
+				violation := newViolation(r.Name(), CodeContainsUserAttribute, nil, attribute.name, map[string]any{"attribute": attribute.name})
+				return false, &violation
+			}
+		}
+	}
+
+	return true, nil
+}
+
+func (r *UserAttributeRule) resembles(forms, words []string, token string) bool {
+	tokens := []string{token, NormalizePassword(token)}
+	for _, form := range forms {
+		for _, t := range tokens {
+			if r.MaxSimilarity > 0 && similarity(form, t) >= r.MaxSimilarity {
+				return true
+			}
+			runes := []rune(t)
+			length := max(r.MinSubstringLength, 1)
+			if len(runes) < length && slices.Contains(words, t) {
+				return true
+			}
+			for i := 0; i+length <= len(runes); i++ {
+				if strings.Contains(form, string(runes[i:i+length])) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// passwordWords splits password at characters other than letters and digits
+// and before an uppercase letter following a lowercase one, and returns each
+// word lowercase, without leading and trailing digits and l33t-normalized,
+// each also reversed.
+func passwordWords(password string) []string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) == 0 {
+			return
+		}
+		lower := strings.ToLower(string(word))
+		for _, form := range []string{lower, strings.TrimFunc(lower, unicode.IsDigit), NormalizePassword(lower)} {
+			words = append(words, form, reverse(form))
+		}
+		word = word[:0]
+	}
+	for _, char := range password {
+		switch {
+		case !unicode.IsLetter(char) && !unicode.IsDigit(char):
+			flush()
+			continue
+		case unicode.IsUpper(char) && len(word) > 0 && unicode.IsLower(word[len(word)-1]):
+			flush()
+		}
+		word = append(word, char)
+	}
+	flush()
+	return words
+}
+
+// passwordForms returns the lowercase and l33t-normalized password, each also
+// reversed.
+func passwordForms(password string) []string {
+	lower := strings.ToLower(password)
+	normalized := NormalizePassword(password)
+	return []string{lower, reverse(lower), normalized, reverse(normalized)}
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+type UserAttributeRuleBuilder struct {
+	UserAttributeRule *UserAttributeRule
+}
+
+func NewUserAttributeRuleBuilder() *UserAttributeRuleBuilder {
+	return &UserAttributeRuleBuilder{UserAttributeRule: NewUserAttributeRule()}
+}
+
+func (builder *UserAttributeRuleBuilder) WithMinSubstringLength(minSubstringLength int) *UserAttributeRuleBuilder {
+	builder.UserAttributeRule.MinSubstringLength = minSubstringLength
+	return builder
+}
+
+func (builder *UserAttributeRuleBuilder) WithMaxSimilarity(maxSimilarity float64) *UserAttributeRuleBuilder {
+	builder.UserAttributeRule.MaxSimilarity = maxSimilarity
+	return builder
+}
+
+func (builder *UserAttributeRuleBuilder) Build() *UserAttributeRule {
+	return builder.UserAttributeRule
+}
@@ -0,0 +1,106 @@
+package rule
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUserAttributeRule_ValidateWithContext(t *testing.T) {
+	ctx := &ValidationContext{
+		Username:    "jdoe42",
+		Email:       "jane.doe@acmecorp.com",
+		DisplayName: "Jane Doe-Smith",
+		CompanyName: "Globex Corporation",
+	}
+
+	testCases := []struct {
+		name      string
+		password  string
+		attribute Code
+	}{
+		{name: "Username", password: "Xq!JDOE42pw", attribute: CodeAttributeUsername},
+		{name: "Reversed username", password: "Xq!24eodjpw", attribute: CodeAttributeUsername},
+		{name: "Email local part", password: "MyJane#2024", attribute: CodeAttributeEmail},
+		{name: "Email domain", password: "Acme#Corp99", attribute: CodeAttributeEmail},
+		{name: "Display name word", password: "smith!Rocks9", attribute: CodeAttributeDisplayName},
+		{name: "Company name in l33t", password: "9l0b3x#Rules", attribute: CodeAttributeCompanyName},
+	}
+
+	r := NewUserAttributeRule()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			valid, err := r.ValidateWithContext(tc.password, ctx)
+			if valid || !errors.Is(err, ErrContainsUserAttribute) {
+				t.Fatalf("ValidateWithContext(%q) = %v, %v, want false, ErrContainsUserAttribute", tc.password, valid, err)
+			}
+			var violation *Violation
+			if errors.As(err, &violation) && violation.Params["attribute"] != tc.attribute {
+				t.Errorf("attribute = %v, want %q", violation.Params["attribute"], tc.attribute)
+			}
+		})
+	}
+
+	if valid, err := r.ValidateWithContext("xK9#mQ2$vL7!", ctx); !valid || err != nil {
+		t.Errorf("ValidateWithContext(unrelated) = %v, %v, want true, nil", valid, err)
+	}
+}
+
+func TestUserAttributeRule_ShortWords(t *testing.T) {
+	ctx := &ValidationContext{DisplayName: "Tom Lee", CompanyName: "Acme Inc"}
+	r := NewUserAttributeRule()
+
+	for _, password := range []string{"Convincing#Sky42", "Tomato&Basil77", "Sleep!Well2024"} {
+		if valid, err := r.ValidateWithContext(password, ctx); !valid || err != nil {
+			t.Errorf("ValidateWithContext(%q) = %v, %v, want true, nil", password, valid, err)
+		}
+	}
+
+	// Words shorter than MinSubstringLength match whole words of a password
+	for _, password := range []string{"Lee!2024", "SkyLee#42", "Tom&Basil77", "b4sil#L33!x"} {
+		if valid, _ := r.ValidateWithContext(password, ctx); valid {
+			t.Errorf("ValidateWithContext(%q) = true, want a short attribute word rejected", password)
+		}
+	}
+}
+
+func TestUserAttributeRule_LocalizedAttribute(t *testing.T) {
+	_, err := NewUserAttributeRule().ValidateWithContext("Xq!JDOE42pw", &ValidationContext{Username: "jdoe42"})
+	violation := err.(*Violation)
+
+	if got, want := violation.Message, "password cannot contain or resemble your username"; got != want {
+		t.Errorf("Message = %q, want %q", got, want)
+	}
+	if got, want := violation.Localize("id"), "kata sandi tidak boleh mengandung atau menyerupai nama pengguna Anda"; got != want {
+		t.Errorf("Localize(id) = %q, want %q", got, want)
+	}
+}
+
+func TestUserAttributeRule_Similarity(t *testing.T) {
+	ctx := &ValidationContext{Username: "marvin"}
+	r := NewUserAttributeRuleBuilder().WithMinSubstringLength(6).WithMaxSimilarity(0.6).Build()
+
+	if valid, _ := r.ValidateWithContext("marbins", ctx); valid {
+		t.Error("password closely resembling the username should be rejected")
+	}
+
+	r = NewUserAttributeRuleBuilder().WithMinSubstringLength(6).WithMaxSimilarity(0).Build()
+	if valid, _ := r.ValidateWithContext("marbins", ctx); !valid {
+		t.Error("similarity check should be disabled when MaxSimilarity is 0")
+	}
+}
+
+func TestUserAttributeRule_NoContext(t *testing.T) {
+	r := NewUserAttributeRule()
+	if valid, err := r.Validate("jdoe42"); !valid || err != nil {
+		t.Errorf("Validate() without context = %v, %v, want true, nil", valid, err)
+	}
+	if valid, err := r.ValidateWithContext("ab", &ValidationContext{Username: "ab"}); !valid || err != nil {
+		t.Errorf("attributes shorter than %d characters should be ignored, got %v, %v", minAttributeTokenLength, valid, err)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	if got := levenshtein([]rune("kitten"), []rune("sitting")); got != 3 {
+		t.Errorf("levenshtein(kitten, sitting) = %d, want 3", got)
+	}
+}
@@ -0,0 +1,130 @@
+package rule
+
+import (
+	"strings"
+)
+
+// Code is a stable, machine-readable identifier of a violation. Codes are
+// errors themselves, so a *Violation can be matched with errors.Is against
+// either a Code or the equivalent Err sentinel.
+type Code string
+
+func (c Code) Error() string {
+	return string(c)
+}
+
+const (
+	CodeEmpty                 Code = "empty"
+	CodeContainsWhitespace    Code = "contains_whitespace"
+	CodeTooShort              Code = "too_short"
+	CodeTooLong               Code = "too_long"
+	CodeMissingUppercase      Code = "missing_uppercase"
+	CodeMissingLowercase      Code = "missing_lowercase"
+	CodeMissingNumber         Code = "missing_number"
+	CodeMissingSymbol         Code = "missing_symbol"
+	CodeLowEntropy            Code = "low_entropy"
+	CodeWeakPassword          Code = "weak_password"
+	CodeBreached              Code = "breached"
+	CodeBlocklisted           Code = "blocklisted"
+	CodeContainsUserAttribute Code = "contains_user_attribute"
+	CodePasswordReused        Code = "password_reused"
+	CodeTooManyRepeats        Code = "too_many_repeats"
+	CodeContainsSequence      Code = "contains_sequence"
+	CodeKeyboardSequence      Code = "keyboard_sequence"
+	CodeTooFewWords           Code = "too_few_words"
+	CodeMissingClass          Code = "missing_class"
+	CodeDisallowedCharacter   Code = "disallowed_character"
+	// CodeRuleFailed is reported for custom rules that return a plain error.
+	CodeRuleFailed Code = "rule_failed"
+)
+
+// Attribute codes name the user attributes of CodeContainsUserAttribute
+// violations. They are catalog entries rather than errors, so that
+// "{attribute}" is rendered in the violation's locale.
+const (
+	CodeAttributeUsername    Code = "attribute_username"
+	CodeAttributeEmail       Code = "attribute_email"
+	CodeAttributeDisplayName Code = "attribute_display_name"
+	CodeAttributeCompanyName Code = "attribute_company_name"
+)
+
+var (
+	ErrEmpty                 error = CodeEmpty
+	ErrContainsWhitespace    error = CodeContainsWhitespace
+	ErrTooShort              error = CodeTooShort
+	ErrTooLong               error = CodeTooLong
+	ErrMissingUppercase      error = CodeMissingUppercase
+	ErrMissingLowercase      error = CodeMissingLowercase
+	ErrMissingNumber         error = CodeMissingNumber
+	ErrMissingSymbol         error = CodeMissingSymbol
+	ErrLowEntropy            error = CodeLowEntropy
+	ErrWeakPassword          error = CodeWeakPassword
+	ErrBreached              error = CodeBreached
+	ErrBlocklisted           error = CodeBlocklisted
+	ErrContainsUserAttribute error = CodeContainsUserAttribute
+	ErrPasswordReused        error = CodePasswordReused
+	ErrTooManyRepeats        error = CodeTooManyRepeats
+	ErrContainsSequence      error = CodeContainsSequence
+	ErrKeyboardSequence      error = CodeKeyboardSequence
+	ErrTooFewWords           error = CodeTooFewWords
+	ErrMissingClass          error = CodeMissingClass
+	ErrDisallowedCharacter   error = CodeDisallowedCharacter
+	ErrRuleFailed            error = CodeRuleFailed
+)
+
+// Violation describes one unmet requirement. Expected is the value required by
+// the rule, Actual the value found in the password and Params the parameters
+// of the rule that was violated.
+type Violation struct {
+	Rule     string
+	Code     Code
+	Expected any
+	Actual   any
+	Params   map[string]any
+	Message  string
+}
+
+func EmptyViolation() *Violation {
+	v := newViolation("required", CodeEmpty, 1, 0, nil)
+	return &v
+}
+
+func (v *Violation) Error() string {
+	return v.Message
+}
+
+func (v *Violation) Unwrap() error {
+	return v.Code
+}
+
+type ValidationReport struct {
+	Violations []Violation
+}
+
+func (r *ValidationReport) Valid() bool {
+	return len(r.Violations) == 0
+}
+
+func (r *ValidationReport) Codes() []Code {
+	codes := make([]Code, len(r.Violations))
+	for i, violation := range r.Violations {
+		codes[i] = violation.Code
+	}
+	return codes
+}
+
+func (r *ValidationReport) Unwrap() []error {
+	errs := make([]error, len(r.Violations))
+	for i := range r.Violations {
+		errs[i] = &r.Violations[i]
+	}
+	return errs
+}
+
+func (r *ValidationReport) Error() string {
+	messages := make([]string, len(r.Violations))
+	for i, violation := range r.Violations {
+		messages[i] = violation.Message
+	}
+	return strings.Join(messages, "; ")
+}
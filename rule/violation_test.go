@@ -0,0 +1,126 @@
+package rule
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPaswotRule_Report(t *testing.T) {
+	paswotRule := DefaultRule()
+
+	t.Run("Valid", func(t *testing.T) {
+		report := paswotRule.Report("Valid123!")
+		if !report.Valid() {
+			t.Errorf("Report() should be valid, got violations %v", report.Codes())
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		report := paswotRule.Report("")
+		if !reflect.DeepEqual(report.Codes(), []Code{CodeEmpty}) {
+			t.Errorf("Report() codes = %v, want %v", report.Codes(), []Code{CodeEmpty})
+		}
+	})
+
+	t.Run("AllViolations", func(t *testing.T) {
+		report := paswotRule.Report("a b")
+		want := []Code{CodeContainsWhitespace, CodeTooShort, CodeMissingUppercase, CodeMissingNumber, CodeMissingSymbol}
+		if !reflect.DeepEqual(report.Codes(), want) {
+			t.Fatalf("Report() codes = %v, want %v", report.Codes(), want)
+		}
+
+		tooShort := report.Violations[1]
+		if tooShort.Rule != "length" || tooShort.Expected != 8 || tooShort.Actual != 3 {
+			t.Errorf("Length violation = %+v, want rule length, expected 8, actual 3", tooShort)
+		}
+
+		wantMessage := "password cannot contain whitespace; password length must be between 8 and 16; " +
+			"password must contain at least 1 uppercase characters; password must contain at least 1 number characters; " +
+			"password must contain at least 1 symbol characters"
+		if report.Error() != wantMessage {
+			t.Errorf("Error() = %q, want %q", report.Error(), wantMessage)
+		}
+	})
+
+	t.Run("TooLong", func(t *testing.T) {
+		report := paswotRule.Report("Valid123!Valid123!")
+		if !reflect.DeepEqual(report.Codes(), []Code{CodeTooLong}) {
+			t.Fatalf("Report() codes = %v, want %v", report.Codes(), []Code{CodeTooLong})
+		}
+		if v := report.Violations[0]; v.Expected != 16 || v.Actual != 18 {
+			t.Errorf("Length violation = %+v, want expected 16, actual 18", v)
+		}
+	})
+}
+
+func TestViolation_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		validate func() (bool, error)
+		want     error
+		params   map[string]any
+	}{
+		{
+			name:     "Too short",
+			validate: func() (bool, error) { return NewLengthRule(8, 16).Validate("short") },
+			want:     ErrTooShort,
+			params:   map[string]any{"min": 8, "max": 16},
+		},
+		{
+			name:     "Too long",
+			validate: func() (bool, error) { return NewLengthRule(1, 2).Validate("long") },
+			want:     ErrTooLong,
+			params:   map[string]any{"min": 1, "max": 2},
+		},
+		{
+			name:     "Missing uppercase",
+			validate: func() (bool, error) { return NewCharacterRule(2, 0, 0, 0).Validate("Abc") },
+			want:     ErrMissingUppercase,
+			params:   map[string]any{"min": 2},
+		},
+		{
+			name:     "Missing symbol",
+			validate: func() (bool, error) { return NewCharacterRule(0, 0, 0, 1).Validate("Abc") },
+			want:     ErrMissingSymbol,
+			params:   map[string]any{"min": 1},
+		},
+		{
+			name:     "Contains whitespace",
+			validate: func() (bool, error) { return NewNoWhitespaceRule().Validate("a b") },
+			want:     ErrContainsWhitespace,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.validate()
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("Validate() error = %v, want errors.Is %v", err, tc.want)
+			}
+
+			var violation *Violation
+			if !errors.As(err, &violation) {
+				t.Fatalf("Validate() error = %T, want *Violation", err)
+			}
+			if violation.Code.Error() != tc.want.Error() {
+				t.Errorf("Violation.Code = %q, want %q", violation.Code, tc.want)
+			}
+			if !reflect.DeepEqual(violation.Params, tc.params) {
+				t.Errorf("Violation.Params = %v, want %v", violation.Params, tc.params)
+			}
+		})
+	}
+}
+
+func TestValidationReport_Unwrap(t *testing.T) {
+	report := DefaultRule().Report("abc")
+	for _, want := range []error{ErrTooShort, ErrMissingUppercase, ErrMissingNumber, ErrMissingSymbol} {
+		if !errors.Is(report, want) {
+			t.Errorf("errors.Is(report, %v) = false, want true", want)
+		}
+	}
+	if errors.Is(report, ErrMissingLowercase) {
+		t.Errorf("errors.Is(report, %v) = true, want false", ErrMissingLowercase)
+	}
+}